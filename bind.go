@@ -1,25 +1,61 @@
 package zinc
 
 import (
+	"database/sql"
 	"database/sql/driver"
 	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
+	"unicode"
 )
 
 func (db *DB) Bind(q string, uArgs UnitedArgs) (string, []any, error) {
+	return db.bindWith(q, uArgs, db.options)
+}
+
+// bindWith is Bind with the options of the call, which may override Converters and TextCharset
+func (db *DB) bindWith(q string, uArgs UnitedArgs, opts *Options) (string, []any, error) {
+	// before binding, so that IN expansion sees driver values
+	uArgs, err := resolveArgs(uArgs, opts.Converters)
+	if err != nil {
+		return "", nil, err
+	}
+	bound, boundArgs, err := db.bind0(q, uArgs)
+	if err != nil {
+		return "", nil, err
+	}
+	boundArgs, err = encodeArgs(boundArgs, opts.TextCharset)
+	if err != nil {
+		return "", nil, err
+	}
+	return bound, boundArgs, nil
+}
+
+func (db *DB) bind0(q string, uArgs UnitedArgs) (string, []any, error) {
 	hasInKeyword := func(s string) bool {
-		return strings.Contains(strings.ToUpper(s), "IN")
+		return inKeywordRegexp.MatchString(s)
 	}
 
-	if uArgs.Empty() {
-		return q, []any{}, nil
+	if db.kind == kindSt {
+		return db.bindStmt(uArgs)
+	} else if uArgs.Empty() {
+		// still rebind so that escaped '??' is unescaped the same way with and without args
+		return rebind(db.Dialect(), q, db.options), []any{}, nil
 	} else if uArgs.HasNamed() {
 		bound, argNames, err := db.Dialect().CompileNamedQuery(q, db.options)
 		if err != nil {
 			return "", nil, err
 		}
+		if isNativeNamedBind(db.Dialect()) {
+			bound, boundArgs, err := bindNamedNative(bound, argNames, uArgs)
+			if err != nil {
+				return "", nil, err
+			}
+			return rebind(db.Dialect(), bound, db.options), boundArgs, nil
+		}
 		boundArgs := append([]any{}, uArgs.Unnamed...)
 		for _, name := range argNames {
 			argVal, ok := uArgs.Named[name]
@@ -29,28 +65,174 @@ func (db *DB) Bind(q string, uArgs UnitedArgs) (string, []any, error) {
 			boundArgs = append(boundArgs, argVal)
 		}
 		if hasInKeyword(bound) {
-			bound, boundArgs, err = bindIn(bound, boundArgs...)
+			bound, boundArgs, err = bindIn(bound, placeholderIndexer(db.Dialect()), boundArgs...)
 			if err != nil {
 				return "", nil, err
 			}
 		}
-		return bound, boundArgs, nil
+		return rebind(db.Dialect(), bound, db.options), boundArgs, nil
 	} else {
 		var err error
 		boundArgs := append([]any{}, uArgs.Unnamed...)
 		if hasInKeyword(q) {
-			q, boundArgs, err = bindIn(q, boundArgs...)
+			q, boundArgs, err = bindIn(q, placeholderIndexer(db.Dialect()), boundArgs...)
 			if err != nil {
 				return "", nil, err
 			}
 		}
-		return q, boundArgs, nil
+		return rebind(db.Dialect(), q, db.options), boundArgs, nil
+	}
+}
+
+// resolveArgs returns a copy of uArgs with registered converters and driver.Valuer applied,
+// the elements of slices for IN expansion are resolved one by one
+func resolveArgs(uArgs UnitedArgs, converters *Converters) (UnitedArgs, error) {
+	var r UnitedArgs
+	if uArgs.Unnamed != nil {
+		r.Unnamed = make(Args, len(uArgs.Unnamed))
+		for i, arg := range uArgs.Unnamed {
+			v, err := resolveArg(arg, converters, true)
+			if err != nil {
+				return UnitedArgs{}, err
+			}
+			r.Unnamed[i] = v
+		}
+	}
+	if uArgs.Named != nil {
+		r.Named = make(NamedArgs, len(uArgs.Named))
+		for name, arg := range uArgs.Named {
+			v, err := resolveArg(arg, converters, true)
+			if err != nil {
+				return UnitedArgs{}, err
+			}
+			r.Named[name] = v
+		}
+	}
+	return r, nil
+}
+
+func resolveArg(arg any, converters *Converters, expand bool) (any, error) {
+	if a, ok := arg.(sql.NamedArg); ok {
+		v, err := resolveArg(a.Value, converters, expand)
+		if err != nil {
+			return nil, err
+		}
+		a.Value = v
+		return a, nil
+	}
+	if v, ok, err := convertArg(arg, converters); err != nil || ok {
+		return v, err
+	}
+	arg, err := valueOf(arg)
+	if err != nil {
+		return nil, err
+	}
+	if sv, ok := asSliceForIn(arg); ok && expand && sliceNeedsResolve(reflect.Indirect(sv), converters) {
+		sv = reflect.Indirect(sv)
+		elems := make([]any, sv.Len())
+		for i := range elems {
+			if elems[i], err = resolveArg(sv.Index(i).Interface(), converters, false); err != nil {
+				return nil, err
+			}
+		}
+		return elems, nil
+	}
+	return arg, nil
+}
+
+// sliceNeedsResolve reports whether any element is a driver.Valuer or has a converter, other
+// slices are left as they are for drivers that take them as arrays
+func sliceNeedsResolve(sv reflect.Value, converters *Converters) bool {
+	for i := 0; i < sv.Len(); i++ {
+		elem := sv.Index(i).Interface()
+		if _, ok := elem.(driver.Valuer); ok {
+			return true
+		}
+		if elem != nil {
+			if conv, ok := converters.Lookup(reflect.TypeOf(elem)); ok && conv.ToDriver != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// valueOf calls Value on a driver.Valuer, a nil pointer whose Value has a value receiver is NULL like in database/sql
+func valueOf(arg any) (any, error) {
+	vr, ok := arg.(driver.Valuer)
+	if !ok {
+		return arg, nil
+	}
+	if rv := reflect.ValueOf(vr); rv.Kind() == reflect.Ptr && rv.IsNil() && rv.Type().Elem().Implements(typValuer) {
+		return nil, nil
+	}
+	return vr.Value()
+}
+
+// inKeywordRegexp matches IN as a word, not inside INSERT or other identifiers
+var inKeywordRegexp = regexp.MustCompile(`(?i)\bIN\b`)
+
+func isNativeNamedBind(d Dialect) bool {
+	nd, ok := d.(NamedBindDialect)
+	return ok && nd.NativeNamedBind()
+}
+
+// bindNamedNative keeps the :name placeholders and passes sql.Named values in the compiled order
+func bindNamedNative(bound string, argNames []string, uArgs UnitedArgs) (string, []any, error) {
+	boundArgs := append([]any{}, uArgs.Unnamed...)
+	seen := map[string]bool{}
+	for _, name := range argNames {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		argVal, ok := uArgs.Named[name]
+		if !ok {
+			return "", nil, fmt.Errorf("missing named arg %s", name)
+		}
+		if v, ok := asSliceForIn(argVal); ok {
+			v = reflect.Indirect(v)
+			if v.Len() == 0 {
+				return "", nil, errors.New("empty slice passed to 'in' query")
+			}
+			expanded := make([]string, 0, v.Len())
+			for i := 0; i < v.Len(); i++ {
+				elemName := name + "_" + strconv.Itoa(i+1)
+				expanded = append(expanded, ":"+elemName)
+				boundArgs = append(boundArgs, sql.Named(elemName, v.Index(i).Interface()))
+			}
+			bound = replaceNamedParam(bound, name, strings.Join(expanded, ", "))
+		} else {
+			boundArgs = append(boundArgs, sql.Named(name, argVal))
+		}
+	}
+	return bound, boundArgs, nil
+}
+
+func replaceNamedParam(q string, name string, replacement string) string {
+	placeholder := ":" + name
+	var buf strings.Builder
+	for {
+		i := strings.Index(q, placeholder)
+		if i < 0 {
+			buf.WriteString(q)
+			return buf.String()
+		}
+		end := i + len(placeholder)
+		isWhole := end >= len(q) || !(unicode.IsOneOf(allowedBindRunes, rune(q[end])) || q[end] == '_' || q[end] == '.')
+		buf.WriteString(q[:i])
+		if isWhole {
+			buf.WriteString(replacement)
+		} else {
+			buf.WriteString(placeholder)
+		}
+		q = q[end:]
 	}
 }
 
 // 下面的代码来自sqlx
 
-func bindIn(query string, args ...any) (string, []any, error) {
+func bindIn(query string, indexFn func(string) int, args ...any) (string, []any, error) {
 	// argMeta stores reflect.Value and length for slices and
 	// the value itself for non-slice arguments
 	type argMeta struct {
@@ -72,12 +254,10 @@ func bindIn(query string, args ...any) (string, []any, error) {
 	}
 
 	for i, arg := range args {
-		if a, ok := arg.(driver.Valuer); ok {
-			var err error
-			arg, err = a.Value()
-			if err != nil {
-				return "", nil, err
-			}
+		if a, err := valueOf(arg); err != nil {
+			return "", nil, err
+		} else {
+			arg = a
 		}
 
 		if v, ok := asSliceForIn(arg); ok {
@@ -109,7 +289,7 @@ func bindIn(query string, args ...any) (string, []any, error) {
 
 	var arg, offset int
 
-	for i := strings.IndexByte(query[offset:], '?'); i != -1; i = strings.IndexByte(query[offset:], '?') {
+	for i := indexFn(query[offset:]); i != -1; i = indexFn(query[offset:]) {
 		if arg >= len(meta) {
 			// if an argument wasn't passed, lets return an error;  this is
 			// not actually how database/sql Exec/Query works, but since we are
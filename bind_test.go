@@ -0,0 +1,122 @@
+package zinc
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testTags []string
+
+type testLevel int
+
+func (l testLevel) Value() (driver.Value, error) {
+	return fmt.Sprintf("L%d", int(l)), nil
+}
+
+type testPtrValuer struct{}
+
+func (testPtrValuer) Value() (driver.Value, error) {
+	return "v", nil
+}
+
+func newBindTestDB(t *testing.T, driverName string) *DB {
+	t.Helper()
+	convs := NewConverters()
+	RegisterConverter(convs, nil, func(tags testTags) (driver.Value, error) {
+		b, err := json.Marshal([]string(tags))
+		return string(b), err
+	})
+	sqlDB, _ := openTestDB(t)
+	db, err := New(driverName, sqlDB, &Options{Converters: convs})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestBindConvertersBeforeIn(t *testing.T) {
+	db := newBindTestDB(t, "mysql")
+	tags := testTags{"a", "b"}
+	var nilValuer *testPtrValuer
+	tests := []struct {
+		q       string
+		args    []any
+		bound   string
+		wantArg []any
+	}{
+		// "INSERT" contains "IN", the converted slice must not be expanded
+		{"INSERT INTO t (tags) VALUES (?)", []any{tags}, "INSERT INTO t (tags) VALUES (?)", []any{`["a","b"]`}},
+		{"INSERT INTO t (tags) VALUES (:tags)", []any{map[string]any{"tags": &tags}}, "INSERT INTO t (tags) VALUES (?)", []any{`["a","b"]`}},
+		{"SELECT * FROM t WHERE l IN (?) AND x = ?", []any{[]testLevel{1, 2}, nilValuer}, "SELECT * FROM t WHERE l IN (?, ?) AND x = ?", []any{"L1", "L2", nil}},
+		{"SELECT * FROM t WHERE l IN (:l)", []any{map[string]any{"l": []testLevel{3}}}, "SELECT * FROM t WHERE l IN (?)", []any{"L3"}},
+		{"SELECT * FROM t WHERE l IN (?)", []any{[]int{1, 2}}, "SELECT * FROM t WHERE l IN (?, ?)", []any{1, 2}},
+	}
+	for _, tt := range tests {
+		uArgs, _ := United(tt.args...)
+		bound, boundArgs, err := db.Bind(tt.q, uArgs)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.q, err)
+		}
+		if bound != tt.bound || !reflect.DeepEqual(boundArgs, tt.wantArg) {
+			t.Errorf("Bind(%q) = %q %#v", tt.q, bound, boundArgs)
+		}
+	}
+	if tags[0] != "a" {
+		t.Fatal("args were modified")
+	}
+}
+
+func TestBindKeepsPlainSlices(t *testing.T) {
+	db := newBindTestDB(t, "postgres")
+	arr := []string{"a", "b"}
+	uArgs, _ := United(arr)
+	// no IN, the slice reaches the driver as is, e.g. for a postgres array parameter
+	_, boundArgs, err := db.Bind("INSERT INTO t (a) VALUES (?)", uArgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(fmt.Sprintf("%T", boundArgs[len(boundArgs)-1]), "[]") {
+		t.Fatalf("boundArgs = %#v", boundArgs)
+	}
+}
+
+func TestBindNativeNamedValuer(t *testing.T) {
+	db := newBindTestDB(t, "oracle")
+	uArgs, _ := United(map[string]any{"l": []testLevel{1, 2}, "t": testTags{"x"}})
+	bound, boundArgs, err := db.Bind("SELECT * FROM t WHERE l IN (:l) AND tags = :t", uArgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bound != "SELECT * FROM t WHERE l IN (:l_1, :l_2) AND tags = :t" {
+		t.Fatalf("bound = %q", bound)
+	}
+	want := []any{Named("l_1", "L1"), Named("l_2", "L2"), Named("t", `["x"]`)}
+	if !reflect.DeepEqual(boundArgs, want) {
+		t.Fatalf("boundArgs = %#v", boundArgs)
+	}
+}
+
+func TestConvertersPerCall(t *testing.T) {
+	sqlDB, d := openTestDB(t)
+	db, err := New("mysql", sqlDB, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	convs := NewConverters()
+	RegisterConverter(convs, nil, func(l testLevel) (driver.Value, error) {
+		return "converted", nil
+	})
+	withConverters := func(opts *Options) {
+		opts.Converters = convs
+	}
+	if err := db.RawExec(nil, "UPDATE t SET l = ?", testLevel(1), OptionsModifier(withConverters)); err != nil {
+		t.Fatal(err)
+	}
+	if arg := d.lastArgs()[0].Value; arg != "converted" {
+		t.Fatalf("arg = %v", arg)
+	}
+}
@@ -0,0 +1,43 @@
+package zinc
+
+const (
+	UpsertNone           = 0
+	UpsertOnDuplicateKey = 1
+	UpsertOnConflict     = 2
+	UpsertMerge          = 3
+)
+
+const (
+	PaginationLimitOffset = 0
+	PaginationOffsetFetch = 1
+)
+
+type Capabilities struct {
+	Returning       bool
+	Upsert          int
+	Pagination      int
+	Savepoints      bool
+	MultiStatements bool
+	MaxPlaceholders int // 0 means unlimited
+	LastInsertId    bool
+}
+
+type CapabilitiesDialect interface {
+	Capabilities() Capabilities
+}
+
+func DialectCapabilities(d Dialect) Capabilities {
+	if cd, ok := d.(CapabilitiesDialect); ok {
+		return cd.Capabilities()
+	}
+	// conservative defaults for dialects that don't describe themselves
+	return Capabilities{
+		Upsert:     UpsertNone,
+		Pagination: PaginationLimitOffset,
+		Savepoints: true,
+	}
+}
+
+func (db *DB) Capabilities() Capabilities {
+	return DialectCapabilities(db.Dialect())
+}
@@ -0,0 +1,53 @@
+package zinc
+
+import (
+	"strings"
+	"testing"
+)
+
+// plainDialect hides the optional interfaces of the wrapped dialect
+type plainDialect struct {
+	Dialect
+}
+
+func TestDialectCapabilities(t *testing.T) {
+	tests := []struct {
+		driverName string
+		upsert     int
+		pagination int
+		savepoints bool
+		returning  bool
+	}{
+		{"mysql", UpsertOnDuplicateKey, PaginationLimitOffset, true, false},
+		{"postgres", UpsertOnConflict, PaginationLimitOffset, true, true},
+		{"sqlite3", UpsertOnConflict, PaginationLimitOffset, true, true},
+		{"sqlserver", UpsertMerge, PaginationOffsetFetch, true, false},
+		{"godror", UpsertMerge, PaginationOffsetFetch, true, false},
+		{"clickhouse", UpsertNone, PaginationLimitOffset, false, false},
+	}
+	for _, tt := range tests {
+		d := LookupDialect(tt.driverName)
+		c := DialectCapabilities(d)
+		if c.Upsert != tt.upsert || c.Pagination != tt.pagination || c.Savepoints != tt.savepoints || c.Returning != tt.returning {
+			t.Errorf("%s: %+v", tt.driverName, c)
+		}
+		// the pagination style matches what Paginate generates
+		paginated := paginate(d, "SELECT * FROM t ORDER BY id", 10, 20, nil)
+		if fetch := strings.Contains(paginated, "FETCH NEXT"); fetch != (c.Pagination == PaginationOffsetFetch) {
+			t.Errorf("%s: Pagination %d but Paginate gives %q", tt.driverName, c.Pagination, paginated)
+		}
+	}
+
+	want := Capabilities{Upsert: UpsertNone, Pagination: PaginationLimitOffset, Savepoints: true}
+	if c := DialectCapabilities(plainDialect{mysqlDialect{}}); c != want {
+		t.Errorf("defaults = %+v", c)
+	}
+	sqlDB, _ := openTestDB(t)
+	db, err := New("postgres", sqlDB, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := db.Capabilities(); !c.Returning || c.MaxPlaceholders != 65535 {
+		t.Errorf("db.Capabilities() = %+v", c)
+	}
+}
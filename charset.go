@@ -0,0 +1,110 @@
+package zinc
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+)
+
+// charsetEncoding returns the encoding of charset, nil encoding means no conversion (UTF-8 or binary)
+func charsetEncoding(charset string) (enc encoding.Encoding, validateUTF8 bool, ok bool) {
+	name := strings.ToLower(strings.TrimSpace(charset))
+	switch name {
+	case "", "binary":
+		return nil, false, true
+	case "utf8", "utf8mb4", "utf8mb3", "utf-8":
+		return nil, true, true
+	case "gbk", "gb2312", "cp936":
+		return simplifiedchinese.GBK, false, true
+	case "gb18030":
+		return simplifiedchinese.GB18030, false, true
+	case "big5":
+		return traditionalchinese.Big5, false, true
+	case "latin1":
+		// latin1 in mysql is actually cp1252
+		return charmap.Windows1252, false, true
+	case "iso-8859-1", "iso8859-1":
+		return charmap.ISO8859_1, false, true
+	}
+	if enc, err := htmlindex.Get(name); err == nil {
+		return enc, false, true
+	}
+	return nil, false, false
+}
+
+func b2s(b []byte, charset string) (string, bool) {
+	enc, validateUTF8, ok := charsetEncoding(charset)
+	if !ok {
+		return "", false
+	}
+	if enc == nil {
+		if validateUTF8 && !utf8.Valid(b) {
+			return "", false
+		}
+		return string(b), true
+	}
+	decoded, err := enc.NewDecoder().Bytes(b)
+	if err != nil {
+		return "", false
+	}
+	return string(decoded), true
+}
+
+func s2b(s string, charset string) ([]byte, bool) {
+	enc, _, ok := charsetEncoding(charset)
+	if !ok {
+		return nil, false
+	}
+	if enc == nil {
+		return []byte(s), true
+	}
+	encoded, err := enc.NewEncoder().Bytes([]byte(s))
+	if err != nil {
+		return nil, false
+	}
+	return encoded, true
+}
+
+func encodeArgs(args []any, charset string) ([]any, error) {
+	enc, _, ok := charsetEncoding(charset)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCharset, charset)
+	}
+	if enc == nil {
+		return args, nil
+	}
+	encodeString := func(s string) ([]byte, error) {
+		b, ok := s2b(s, charset)
+		if !ok {
+			return nil, fmt.Errorf("failed to encode string arg to %s", charset)
+		}
+		return b, nil
+	}
+	for i, arg := range args {
+		switch a := arg.(type) {
+		case string:
+			b, err := encodeString(a)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = b
+		case sql.NamedArg:
+			if s, ok := a.Value.(string); ok {
+				b, err := encodeString(s)
+				if err != nil {
+					return nil, err
+				}
+				a.Value = b
+				args[i] = a
+			}
+		}
+	}
+	return args, nil
+}
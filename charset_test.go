@@ -0,0 +1,68 @@
+package zinc
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"testing"
+)
+
+func withCharset(charset string) OptionsModifier {
+	return func(opts *Options) {
+		opts.TextCharset = charset
+	}
+}
+
+func TestCharsetEncodeDecode(t *testing.T) {
+	sqlDB, d := openTestDB(t)
+	db, err := New("mysql", sqlDB, &Options{TextCharset: "gbk"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cols := []testColumn{{name: "s", typeName: "VARCHAR", scanType: typRawBytes}}
+	gbk := []byte{0xc4, 0xe3, 0xba, 0xc3} // 你好
+
+	if err := db.RawExec(nil, "INSERT INTO t (s) VALUES (?)", "你好"); err != nil {
+		t.Fatal(err)
+	}
+	if arg := d.lastArgs()[0].Value; !bytes.Equal(arg.([]byte), gbk) {
+		t.Fatalf("encoded arg = %x", arg)
+	}
+	d.setRows(cols, []driver.Value{gbk})
+	var s string
+	if err := db.RawQueryOne(&s, "SELECT"); err != nil || s != "你好" {
+		t.Fatalf("s = %q, %v", s, err)
+	}
+
+	// a per-call charset is used for both the args and the rows
+	if err := db.RawExec(nil, "INSERT INTO t (s) VALUES (?)", "é", withCharset("latin1")); err != nil {
+		t.Fatal(err)
+	}
+	if arg := d.lastArgs()[0].Value; !bytes.Equal(arg.([]byte), []byte{0xe9}) {
+		t.Fatalf("encoded arg = %x", arg)
+	}
+	d.setRows(cols, []driver.Value{[]byte{0xe9}})
+	if err := db.RawQueryOne(&s, "SELECT", withCharset("latin1")); err != nil || s != "é" {
+		t.Fatalf("s = %q, %v", s, err)
+	}
+
+	if err := db.RawExec(nil, "INSERT INTO t (s) VALUES (?)", "x", withCharset("no-such-charset")); err == nil {
+		t.Fatal("unsupported per-call charset was accepted")
+	}
+}
+
+func TestCharsetInvalidUTF8(t *testing.T) {
+	sqlDB, d := openTestDB(t)
+	db, err := New("mysql", sqlDB, &Options{TextCharset: "utf8mb4"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.setRows([]testColumn{{name: "s", typeName: "VARCHAR", scanType: typRawBytes}}, []driver.Value{[]byte{0xff, 0xfe}})
+	var s string
+	if err := db.RawQueryOne(&s, "SELECT"); err == nil {
+		t.Fatalf("invalid UTF-8 = %q", s)
+	}
+	var m map[string]any
+	if err := db.RawQueryOne(&m, "SELECT"); err == nil {
+		t.Fatalf("invalid UTF-8 = %v", m)
+	}
+}
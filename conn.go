@@ -0,0 +1,26 @@
+package zinc
+
+import (
+	"context"
+	"database/sql"
+)
+
+func (db *DB) Conn(ctx context.Context) (*DB, error) {
+	if db.kind != kindDB {
+		return nil, ErrInvalidKind
+	}
+	if ctx == nil {
+		ctx = db.getCtx()
+	}
+	conn, err := db.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cloned := db.clone(kindCn, db.db, conn, nil, nil)
+	cloned.ctx = ctx
+	return cloned, nil
+}
+
+func (db *DB) RawConn() *sql.Conn {
+	return db.conn
+}
@@ -0,0 +1,54 @@
+package zinc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConn(t *testing.T) {
+	sqlDB, d := openTestDB(t)
+	sqlDB.SetMaxOpenConns(1)
+	db, err := New("mysql", sqlDB, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	c, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.RawConn() == nil {
+		t.Fatal("RawConn is nil")
+	}
+	if _, err := c.Conn(ctx); !errors.Is(err, ErrInvalidKind) {
+		t.Fatalf("Conn of a conn: %v", err)
+	}
+	// session state set by one statement is seen by the next one on the same connection
+	if err := c.RawExec(nil, "SET @x = 1"); err != nil {
+		t.Fatal(err)
+	}
+	err = c.Transaction(ctx, func(tx *DB) error {
+		return tx.RawExec(nil, "UPDATE t SET x = @x")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the only connection is pinned until Close
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if err := db.WithContext(timeoutCtx).RawExec(nil, "SELECT 1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("pool was not exhausted: %v", err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.RawExec(nil, "SELECT 1"); err != nil {
+		t.Fatal(err)
+	}
+	if countExact(d, "OPEN") != 1 {
+		t.Fatalf("log = %v", d.logged())
+	}
+}
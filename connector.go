@@ -0,0 +1,112 @@
+package zinc
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+)
+
+type ConnInitializer func(ctx context.Context, conn driver.Conn) error
+
+func InitStatements(stmts ...string) ConnInitializer {
+	return func(ctx context.Context, conn driver.Conn) error {
+		for _, stmt := range stmts {
+			if err := execDriverConn(ctx, conn, stmt); err != nil {
+				return fmt.Errorf("init connection (%s): %w", stmt, err)
+			}
+		}
+		return nil
+	}
+}
+
+func openWithConnInit(driverName string, dsn string, connInit ConnInitializer) (*sql.DB, error) {
+	// sql.Open does not connect, it only looks up the driver
+	db0, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	d := db0.Driver()
+	_ = db0.Close()
+
+	var base driver.Connector
+	if dc, ok := d.(driver.DriverContext); ok {
+		base, err = dc.OpenConnector(dsn)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		base = dsnConnector{dsn: dsn, driver: d}
+	}
+	return sql.OpenDB(initConnector{base: base, connInit: connInit}), nil
+}
+
+type dsnConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+func (c dsnConnector) Connect(_ context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+type initConnector struct {
+	base     driver.Connector
+	connInit ConnInitializer
+}
+
+func (c initConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.base.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.connInit(ctx, conn); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (c initConnector) Driver() driver.Driver {
+	return c.base.Driver()
+}
+
+func (c initConnector) Close() error {
+	if closer, ok := c.base.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func execDriverConn(ctx context.Context, conn driver.Conn, q string) error {
+	if execer, ok := conn.(driver.ExecerContext); ok {
+		_, err := execer.ExecContext(ctx, q, nil)
+		if err != driver.ErrSkip {
+			return err
+		}
+	}
+	var st driver.Stmt
+	var err error
+	if preparer, ok := conn.(driver.ConnPrepareContext); ok {
+		st, err = preparer.PrepareContext(ctx, q)
+	} else {
+		st, err = conn.Prepare(q)
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = st.Close()
+	}()
+	if execer, ok := st.(driver.StmtExecContext); ok {
+		_, err = execer.ExecContext(ctx, nil)
+	} else {
+		_, err = st.Exec(nil)
+	}
+	return err
+}
@@ -0,0 +1,69 @@
+package zinc
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestConnInit(t *testing.T) {
+	dsn, d := newTestDSN()
+	var inits int64
+	initStatements := InitStatements("SET NAMES utf8mb4", "SET time_zone = '+00:00'")
+	db, err := Open("zinctest", dsn, &Options{
+		Dialect: mysqlDialect{},
+		ConnInit: func(ctx context.Context, conn driver.Conn) error {
+			atomic.AddInt64(&inits, 1)
+			return initStatements(ctx, conn)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.Close() }()
+
+	// a reused connection is not initialized again
+	for i := 0; i < 2; i++ {
+		if err := db.RawExec(nil, "UPDATE t SET x = 1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := atomic.LoadInt64(&inits); n != 1 {
+		t.Fatalf("%d inits", n)
+	}
+	// while it is pinned a second connection is opened and initialized
+	c, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.RawExec(nil, "UPDATE t SET x = 1"); err != nil {
+		t.Fatal(err)
+	}
+	_ = c.Close()
+	if n := atomic.LoadInt64(&inits); n != 2 || countExact(d, "OPEN") != 2 {
+		t.Fatalf("%d inits: %v", n, d.logged())
+	}
+	if countExact(d, "EXEC SET NAMES utf8mb4") != 2 || countExact(d, "EXEC SET time_zone = '+00:00'") != 2 {
+		t.Fatalf("log = %v", d.logged())
+	}
+}
+
+func TestConnInitError(t *testing.T) {
+	dsn, _ := newTestDSN()
+	errInit := errors.New("init failed")
+	db, err := Open("zinctest", dsn, &Options{
+		Dialect: mysqlDialect{},
+		ConnInit: func(ctx context.Context, conn driver.Conn) error {
+			return errInit
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.Close() }()
+	if err := db.RawExec(nil, "UPDATE t SET x = 1"); !errors.Is(err, errInit) {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package zinc
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Converter converts between a Go type and database values for types that don't
+// implement sql.Scanner or driver.Valuer themselves
+type Converter struct {
+	// FromDB converts a database value, already normalized by the dialect (text as
+	// string, exact decimals as string, times as time.Time), to the Go type
+	FromDB func(v any) (any, error)
+	// ToDriver converts a Go value to a driver.Value passed as a query argument
+	ToDriver func(v any) (driver.Value, error)
+}
+
+// Converters is a registry of converters by Go type, safe for concurrent use
+type Converters struct {
+	mutex      sync.RWMutex
+	converters map[reflect.Type]Converter
+}
+
+func NewConverters() *Converters {
+	return &Converters{converters: map[reflect.Type]Converter{}}
+}
+
+// Register registers the converter for t, replacing an existing one
+func (c *Converters) Register(t reflect.Type, conv Converter) {
+	lockW(&c.mutex, func() {
+		c.converters[t] = conv
+	})
+}
+
+// Lookup returns the converter registered for t
+func (c *Converters) Lookup(t reflect.Type) (Converter, bool) {
+	if c == nil || t == nil {
+		return Converter{}, false
+	}
+	var conv Converter
+	var ok bool
+	lockR(&c.mutex, func() {
+		conv, ok = c.converters[t]
+	})
+	return conv, ok
+}
+
+// RegisterConverter registers typed conversions for T, either function may be nil
+func RegisterConverter[T any](c *Converters, fromDB func(v any) (T, error), toDriver func(v T) (driver.Value, error)) {
+	var conv Converter
+	if fromDB != nil {
+		conv.FromDB = func(v any) (any, error) {
+			return fromDB(v)
+		}
+	}
+	if toDriver != nil {
+		conv.ToDriver = func(v any) (driver.Value, error) {
+			return toDriver(v.(T))
+		}
+	}
+	c.Register(reflect.TypeOf((*T)(nil)).Elem(), conv)
+}
+
+// hasFromDBConverter reports whether values of t are produced by a registered converter
+func hasFromDBConverter(t reflect.Type, opts *Options) bool {
+	if opts == nil {
+		return false
+	}
+	conv, ok := opts.Converters.Lookup(t)
+	return ok && conv.FromDB != nil
+}
+
+// convertFromDB converts a normalized, non-NULL database value with the converter of toType
+func convertFromDB(v reflect.Value, toType reflect.Type, opts *Options) (reflect.Value, error) {
+	conv, _ := opts.Converters.Lookup(toType)
+	var src any
+	if v.IsValid() {
+		src = v.Interface()
+	}
+	r, err := conv.FromDB(src)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	rv := reflect.ValueOf(r)
+	if !rv.IsValid() || rv.Type() != toType {
+		return reflect.Value{}, fmt.Errorf("converter for %s returned %T", toType, r)
+	}
+	return rv, nil
+}
+
+// convertArg converts an arg of a registered type, or a pointer to one, to its driver value
+func convertArg(arg any, converters *Converters) (any, bool, error) {
+	if converters == nil || arg == nil {
+		return arg, false, nil
+	}
+	v := reflect.ValueOf(arg)
+	conv, ok := converters.Lookup(v.Type())
+	if !ok && v.Kind() == reflect.Ptr {
+		if conv, ok = converters.Lookup(v.Type().Elem()); ok && conv.ToDriver != nil {
+			if v.IsNil() {
+				return nil, true, nil
+			}
+			arg = v.Elem().Interface()
+		}
+	}
+	if !ok || conv.ToDriver == nil {
+		return arg, false, nil
+	}
+	r, err := conv.ToDriver(arg)
+	if err != nil {
+		return nil, false, err
+	}
+	return r, true, nil
+}
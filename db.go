@@ -3,58 +3,102 @@ package zinc
 import (
 	"context"
 	"database/sql"
+	"fmt"
 )
 
 type DB struct {
-	kind    int
-	db      *sql.DB
-	tx      *sql.Tx
-	st      *sql.Stmt
-	options *Options
-	ctx     context.Context
+	kind     int
+	db       *sql.DB
+	conn     *sql.Conn
+	tx       *sql.Tx
+	st       *sql.Stmt
+	options  *Options
+	ctx      context.Context
+	txDepth  int
+	txState  *txState
+	stQuery  string
+	stNames  []string
+	stmts    *stmtCache
+	replicas *replicaSet
 }
 
 const (
 	kindDB = 1
 	kindTx = 2
 	kindSt = 3
+	kindCn = 4
 )
 
 func New(driverName string, db *sql.DB, opts *Options) (*DB, error) {
 	opts1 := fromPtr(opts)
 	if opts1.Dialect == nil {
 		dialect := dialectOf(driverName)
+		if dialect == nil {
+			dialect = detectDialect(db)
+		}
 		if dialect == nil {
 			return nil, ErrUnsupportedDriver
 		}
 		opts1.Dialect = dialect
 	}
+	if _, _, ok := charsetEncoding(opts1.TextCharset); !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCharset, opts1.TextCharset)
+	}
 	if opts1.NameResolver == nil {
 		opts1.NameResolver = DefaultNameResolver
 	}
+	var stmts *stmtCache
+	if opts1.StmtCacheSize > 0 {
+		stmts = newStmtCache(opts1.StmtCacheSize)
+	}
 	return &DB{
 		kind:    kindDB,
 		db:      db,
 		tx:      nil,
 		st:      nil,
 		options: &opts1,
+		stmts:   stmts,
 	}, nil
 }
 
 func Open(driverName string, dsn string, opts *Options) (*DB, error) {
-	db, err := sql.Open(driverName, dsn)
+	var db *sql.DB
+	var err error
+	if opts != nil && opts.ConnInit != nil {
+		db, err = openWithConnInit(driverName, dsn, opts.ConnInit)
+	} else {
+		db, err = sql.Open(driverName, dsn)
+	}
 	if err != nil {
 		return nil, err
 	}
-	return New(driverName, db, opts)
+	zdb, err := New(driverName, db, opts)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return zdb, nil
 }
 
 func (db *DB) Close() error {
 	switch db.kind {
 	case kindDB:
+		if db.stmts != nil {
+			_ = db.stmts.close()
+		}
+		if db.replicas != nil {
+			if err := db.replicas.close(); err != nil {
+				_ = db.db.Close()
+				return err
+			}
+		}
 		return db.db.Close()
+	case kindTx:
+		return closeTx(db.tx)
 	case kindSt:
 		return db.st.Close()
+	case kindCn:
+		return db.conn.Close()
 	default:
 		panic("invalid kind")
 	}
@@ -68,16 +112,30 @@ func (db *DB) Dialect() Dialect {
 	return db.options.Dialect
 }
 
+func (db *DB) Quote(s string) (string, error) {
+	quoted := db.Dialect().Quote(s, db.options)
+	if quoted == "" {
+		return "", fmt.Errorf("%w: %q", ErrInvalidIdentifier, s)
+	}
+	return quoted, nil
+}
+
 func (db *DB) WithContext(ctx context.Context) *DB {
+	if db.kind == kindDB {
+		if tx, ok := TxFromContext(ctx); ok && tx.db == db.db {
+			db = tx
+		}
+	}
 	cloned := *db
 	cloned.ctx = ctx
 	return &cloned
 }
 
-func (db *DB) clone(newKind int, newDB *sql.DB, newTx *sql.Tx, newStmt *sql.Stmt) *DB {
+func (db *DB) clone(newKind int, newDB *sql.DB, newConn *sql.Conn, newTx *sql.Tx, newStmt *sql.Stmt) *DB {
 	cloned := *db
 	cloned.kind = newKind
 	cloned.db = newDB
+	cloned.conn = newConn
 	cloned.tx = newTx
 	cloned.st = newStmt
 	return &cloned
@@ -89,3 +147,7 @@ func (db *DB) getCtx() context.Context {
 	}
 	return db.ctx
 }
+
+func (db *DB) Paginate(q string, limit, offset int64) string {
+	return paginate(db.Dialect(), q, limit, offset, db.options)
+}
@@ -2,10 +2,15 @@ package zinc
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"errors"
+	"fmt"
+	"math"
+	"math/big"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 )
 
@@ -17,13 +22,77 @@ type Dialect interface {
 	CoerceDest(ci *sql.ColumnType, scannedVal reflect.Value, toType reflect.Type, opts *Options) (reflect.Value, error)
 }
 
-func dialectOf(driverName string) Dialect {
-	switch driverName {
-	case "mysql":
-		return mysqlDialect{}
-	default:
-		return nil
+type SavepointDialect interface {
+	SavepointSQL(name string, opts *Options) string
+	RollbackToSavepointSQL(name string, opts *Options) string
+	ReleaseSavepointSQL(name string, opts *Options) string
+}
+
+type RebindDialect interface {
+	Rebind(q string, opts *Options) string
+}
+
+type NamedBindDialect interface {
+	NativeNamedBind() bool
+}
+
+type PaginationDialect interface {
+	Paginate(q string, limit, offset int64, opts *Options) string
+}
+
+type RetryableErrorDialect interface {
+	IsRetryableError(err error) bool
+}
+
+func rebind(d Dialect, q string, opts *Options) string {
+	if rd, ok := d.(RebindDialect); ok {
+		return rd.Rebind(q, opts)
 	}
+	return q
+}
+
+func paginate(d Dialect, q string, limit, offset int64, opts *Options) string {
+	if pd, ok := d.(PaginationDialect); ok {
+		return pd.Paginate(q, limit, offset, opts)
+	}
+	return paginateLimitOffset(q, limit, offset, "")
+}
+
+// paginateLimitOffset appends LIMIT/OFFSET, noLimit is used as LIMIT when only offset is given
+func paginateLimitOffset(q string, limit, offset int64, noLimit string) string {
+	q = strings.TrimRight(q, " \t\r\n;")
+	if limit > 0 {
+		q += " LIMIT " + strconv.FormatInt(limit, 10)
+	} else if offset > 0 && noLimit != "" {
+		q += " LIMIT " + noLimit
+	}
+	if offset > 0 {
+		q += " OFFSET " + strconv.FormatInt(offset, 10)
+	}
+	return q
+}
+
+func savepointDialectOf(d Dialect) SavepointDialect {
+	if sd, ok := d.(SavepointDialect); ok {
+		return sd
+	}
+	return standardSavepointDialect{d}
+}
+
+type standardSavepointDialect struct {
+	d Dialect
+}
+
+func (sd standardSavepointDialect) SavepointSQL(name string, opts *Options) string {
+	return "SAVEPOINT " + sd.d.Quote(name, opts)
+}
+
+func (sd standardSavepointDialect) RollbackToSavepointSQL(name string, opts *Options) string {
+	return "ROLLBACK TO SAVEPOINT " + sd.d.Quote(name, opts)
+}
+
+func (sd standardSavepointDialect) ReleaseSavepointSQL(name string, opts *Options) string {
+	return "RELEASE SAVEPOINT " + sd.d.Quote(name, opts)
 }
 
 const (
@@ -39,31 +108,112 @@ const (
 	quoteSingle
 	quoteDouble
 	quoteBack
+	quoteBracket
 )
 
 var allowedBindRunes = []*unicode.RangeTable{unicode.Letter, unicode.Digit}
 
+// quote quotes a possibly qualified identifier like schema.table.column, each part is
+// quoted separately with embedded quote characters escaped; invalid identifiers give ""
 func quote(s string, quoteType int) string {
-	s = strings.TrimSpace(s)
-	if s == "" {
-		return ""
-	}
-	var b byte
+	return quoteWith(s, quoteType, nil)
+}
+
+// quoteWith is quote with unquotedFn applied to the parts that were not quoted by the caller
+func quoteWith(s string, quoteType int, unquotedFn func(string) string) string {
+	var left, right byte
 	switch quoteType {
 	case quoteSingle, quoteUnknown:
-		b = '\''
+		left, right = '\'', '\''
 	case quoteDouble:
-		b = '"'
+		left, right = '"', '"'
 	case quoteBack:
-		b = '`'
+		left, right = '`', '`'
+	case quoteBracket:
+		left, right = '[', ']'
 	default:
 		panic("unhandled quoteType")
 	}
 
-	if len(s) >= 2 && s[0] == b && s[len(s)-1] == b {
-		return s
-	} else {
-		return string(b) + s + string(b)
+	parts, ok := splitIdentifier(strings.TrimSpace(s), left, right)
+	if !ok {
+		return ""
+	}
+	var buf strings.Builder
+	for i, part := range parts {
+		name := part.name
+		if !part.quoted && unquotedFn != nil {
+			name = unquotedFn(name)
+		}
+		if name == "" || strings.IndexByte(name, 0) >= 0 {
+			return ""
+		}
+		if i > 0 {
+			buf.WriteByte('.')
+		}
+		buf.WriteByte(left)
+		buf.WriteString(strings.ReplaceAll(name, string(right), string(right)+string(right)))
+		buf.WriteByte(right)
+	}
+	return buf.String()
+}
+
+type identifierPart struct {
+	name   string
+	quoted bool
+}
+
+// splitIdentifier splits a dotted identifier, parts already wrapped in left/right are unescaped
+func splitIdentifier(s string, left, right byte) ([]identifierPart, bool) {
+	if s == "" {
+		return nil, false
+	}
+	var parts []identifierPart
+	for {
+		var part identifierPart
+		if s != "" && s[0] == left {
+			var buf strings.Builder
+			i := 1
+			closed := false
+			for i < len(s) {
+				if s[i] == right {
+					if i+1 < len(s) && s[i+1] == right {
+						buf.WriteByte(right)
+						i += 2
+						continue
+					}
+					closed = true
+					i++
+					break
+				}
+				buf.WriteByte(s[i])
+				i++
+			}
+			if !closed {
+				return nil, false
+			}
+			part = identifierPart{name: buf.String(), quoted: true}
+			s = s[i:]
+			if s != "" && s[0] != '.' {
+				return nil, false
+			}
+		} else {
+			i := strings.IndexByte(s, '.')
+			if i < 0 {
+				i = len(s)
+			}
+			part = identifierPart{name: strings.TrimSpace(s[:i])}
+			s = s[i:]
+		}
+		parts = append(parts, part)
+		if s == "" {
+			return parts, true
+		}
+		// skip the '.'
+		s = strings.TrimSpace(s[1:])
+		if s == "" {
+			return nil, false
+		}
 	}
 }
 
@@ -147,34 +297,495 @@ func compileNamedQuery(qs []byte, bindType int) (query string, names []string, e
 	return string(rebound), names, err
 }
 
+// rebindQuestion rewrites the positional '?' for bindType, skipping quoted literals, comments
+// and dollar-quoted bodies; '??' is an escaped '?', e.g. for the postgres jsonb operators ?, ?| and ?&
+func rebindQuestion(q string, bindType int) string {
+	if bindType == bindQuestion || bindType == bindUnknown || strings.IndexByte(q, '?') < 0 {
+		return q
+	}
+	rebound := make([]byte, 0, len(q)+10)
+	currentVar := 1
+	for i := 0; i < len(q); {
+		if j := skipNonCode(q, i); j > i {
+			rebound = append(rebound, q[i:j]...)
+			i = j
+			continue
+		}
+		b := q[i]
+		if b != '?' {
+			rebound = append(rebound, b)
+			i++
+			continue
+		}
+		if i+1 < len(q) && q[i+1] == '?' {
+			rebound = append(rebound, '?')
+			i += 2
+			continue
+		}
+		switch bindType {
+		case bindDollar:
+			rebound = append(rebound, '$')
+		case bindAt:
+			rebound = append(rebound, '@', 'p')
+		case bindNamed:
+			rebound = append(rebound, ':')
+		default:
+			panic("unhandled bindType")
+		}
+		rebound = strconv.AppendInt(rebound, int64(currentVar), 10)
+		currentVar++
+		i++
+	}
+	return string(rebound)
+}
+
+// placeholderIndexer returns how '?' placeholders are found in the queries of d, dialects that
+// rebind skip literals, comments and escaped '??' like rebindQuestion does, for the others every
+// '?' is a placeholder, e.g. MySQL literals may contain backslash escaped quotes
+func placeholderIndexer(d Dialect) func(string) int {
+	if _, ok := d.(RebindDialect); ok {
+		return indexPlaceholder
+	}
+	return indexQuestion
+}
+
+func indexQuestion(q string) int {
+	return strings.IndexByte(q, '?')
+}
+
+// indexPlaceholder returns the index of the first '?' placeholder in q, like rebindQuestion it
+// skips quoted literals, comments, dollar-quoted bodies and escaped '??'
+func indexPlaceholder(q string) int {
+	for i := 0; i < len(q); {
+		if j := skipNonCode(q, i); j > i {
+			i = j
+			continue
+		}
+		if q[i] == '?' {
+			if i+1 < len(q) && q[i+1] == '?' {
+				i += 2
+				continue
+			}
+			return i
+		}
+		i++
+	}
+	return -1
+}
+
+// skipNonCode returns the end of the quoted literal, comment or dollar-quoted body starting
+// at q[i], or i if there is none; unterminated ones run to the end of q
+func skipNonCode(q string, i int) int {
+	switch b := q[i]; {
+	case b == '\'' || b == '"' || b == '`':
+		if j := strings.IndexByte(q[i+1:], b); j >= 0 {
+			return i + 1 + j + 1
+		}
+		return len(q)
+	case b == '-' && strings.HasPrefix(q[i:], "--"):
+		if j := strings.IndexByte(q[i:], '\n'); j >= 0 {
+			return i + j + 1
+		}
+		return len(q)
+	case b == '/' && strings.HasPrefix(q[i:], "/*"):
+		if j := strings.Index(q[i+2:], "*/"); j >= 0 {
+			return i + 2 + j + 2
+		}
+		return len(q)
+	case b == '$':
+		tag, ok := dollarQuoteTag(q[i:])
+		if !ok {
+			return i
+		}
+		if j := strings.Index(q[i+len(tag):], tag); j >= 0 {
+			return i + len(tag) + j + len(tag)
+		}
+		return len(q)
+	default:
+		return i
+	}
+}
+
+// dollarQuoteTag returns the opening tag of a dollar-quoted string like $$ or $body$, '$1' is not one
+func dollarQuoteTag(s string) (string, bool) {
+	for j := 1; j < len(s); j++ {
+		b := s[j]
+		switch {
+		case b == '$':
+			return s[:j+1], true
+		case b == '_' || unicode.IsLetter(rune(b)) || (j > 1 && b >= '0' && b <= '9'):
+		default:
+			return "", false
+		}
+	}
+	return "", false
+}
+
 // coerce dest
 func coerceDest(_ *sql.ColumnType, scannedVal reflect.Value, toType reflect.Type, opts *Options) (reflect.Value, error) {
-	if scannedVal.Type() == toType {
+	if isNullValue(scannedVal) {
+		return coerceNull(toType, opts)
+	}
+	if toType == typAny {
 		return scannedVal, nil
 	}
+	if hasFromDBConverter(toType, opts) {
+		return convertFromDB(scannedVal, toType, opts)
+	}
+	v := scannedVal
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if v.Type() == toType {
+		return v, nil
+	}
+	if reflect.PtrTo(toType).Implements(typScanner) {
+		// sql.NullString, sql.Null[T] and friends
+		return scanInto(v, toType)
+	}
 
-	// TODO: more coerce
 	switch toType {
-	case typAny:
-		return scannedVal, nil
-	case typString:
-		switch a := scannedVal.Interface().(type) {
-		case []byte:
-			s, ok := b2s(a, opts.TextCharset)
-			if !ok {
-				return reflect.Value{}, errors.New("failed to convert []byte to string")
-			}
-			return reflect.ValueOf(s), nil
-		case sql.RawBytes:
-			s, ok := b2s(a, opts.TextCharset)
-			if !ok {
-				return reflect.Value{}, errors.New("failed to convert sql.RawBytes to string")
-			}
-			return reflect.ValueOf(s), nil
+	case typTime:
+		if s, ok := coerceText(v, opts); ok {
+			t, err := parseTimeText(s, opts)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(t), nil
+		}
+		return reflect.Value{}, fmt.Errorf("can't convert %s to %s", v.Type(), toType)
+	case typDuration:
+		if s, ok := coerceText(v, opts); ok {
+			d, err := parseDurationText(s)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(d), nil
+		}
+		if isIntKind(v.Kind()) {
+			return reflect.ValueOf(time.Duration(v.Int())), nil
+		}
+		return reflect.Value{}, fmt.Errorf("can't convert %s to %s", v.Type(), toType)
+	}
+
+	// the kind matrix, named types like `type UserID int64` are handled by kind
+	fromKind, toKind := v.Kind(), toType.Kind()
+	switch {
+	case isNumberKind(toKind):
+		switch {
+		case isNumberKind(fromKind):
+			return convertNumber(v, toType)
+		case fromKind == reflect.Bool:
+			n := int64(0)
+			if v.Bool() {
+				n = 1
+			}
+			return convertNumber(reflect.ValueOf(n), toType)
+		}
+		if s, ok := coerceText(v, opts); ok {
+			return parseNumberText(s, toType)
+		}
+	case toKind == reflect.Bool:
+		switch {
+		case isIntKind(fromKind):
+			return reflect.ValueOf(v.Int() != 0).Convert(toType), nil
+		case isUintKind(fromKind):
+			return reflect.ValueOf(v.Uint() != 0).Convert(toType), nil
+		case isFloatKind(fromKind):
+			return reflect.ValueOf(v.Float() != 0).Convert(toType), nil
+		case fromKind == reflect.Bool:
+			return v.Convert(toType), nil
+		}
+		if s, ok := coerceText(v, opts); ok {
+			b, err := parseBoolText(s)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(b).Convert(toType), nil
+		}
+	case toKind == reflect.String:
+		if s, ok := formatText(v, opts); ok {
+			return reflect.ValueOf(s).Convert(toType), nil
+		}
+		if isByteSlice(v.Type()) {
+			return reflect.Value{}, fmt.Errorf("failed to convert %s to string", v.Type())
+		}
+	case isByteSlice(toType):
+		if isByteSlice(v.Type()) {
+			// the driver may reuse the scanned buffer
+			return reflect.ValueOf(cloneSlice(v.Bytes())).Convert(toType), nil
+		}
+		if s, ok := formatText(v, opts); ok {
+			return reflect.ValueOf([]byte(s)).Convert(toType), nil
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("can't convert %s to %s", v.Type(), toType)
+}
+
+func isByteSlice(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
+// coerceText returns the text of a string or byte slice value, bytes are decoded with Options.TextCharset
+func coerceText(v reflect.Value, opts *Options) (string, bool) {
+	switch {
+	case v.Kind() == reflect.String:
+		return v.String(), true
+	case isByteSlice(v.Type()):
+		return b2s(v.Bytes(), opts.TextCharset)
+	default:
+		return "", false
+	}
+}
+
+// formatText formats a string, byte slice, number or bool value as text
+func formatText(v reflect.Value, opts *Options) (string, bool) {
+	switch kind := v.Kind(); {
+	case v.Type() == typTime:
+		return v.Interface().(time.Time).Format(time.RFC3339Nano), true
+	case isIntKind(kind):
+		return strconv.FormatInt(v.Int(), 10), true
+	case isUintKind(kind):
+		return strconv.FormatUint(v.Uint(), 10), true
+	case kind == reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 32), true
+	case kind == reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64), true
+	case kind == reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true
+	default:
+		return coerceText(v, opts)
+	}
+}
+
+// parseNumberText parses a decimal text into a number kind, "12.0" is accepted for integers
+func parseNumberText(s string, toType reflect.Type) (reflect.Value, error) {
+	s = strings.TrimSpace(s)
+	switch toKind := toType.Kind(); {
+	case isIntKind(toKind):
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return convertNumber(reflect.ValueOf(n), toType)
+		}
+	case isUintKind(toKind):
+		if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return convertNumber(reflect.ValueOf(n), toType)
+		}
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("invalid number %q", s)
+	}
+	return convertNumber(reflect.ValueOf(f), toType)
+}
+
+// parseBoolText parses strconv.ParseBool forms plus the y/n and on/off spellings
+func parseBoolText(s string) (bool, error) {
+	s = strings.TrimSpace(s)
+	switch strings.ToLower(s) {
+	case "y", "yes", "on":
+		return true, nil
+	case "n", "no", "off":
+		return false, nil
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, fmt.Errorf("invalid bool %q", s)
+	}
+	return b, nil
+}
+
+// newScanDest allocates a scan target of the column's ScanType, a non-nullable
+// scan type is scanned through a pointer so that a NULL becomes a nil pointer
+// instead of failing the scan
+func newScanDest(ci *sql.ColumnType) any {
+	t := ci.ScanType()
+	if t == nil {
+		return new(any)
+	}
+	switch {
+	case t.Kind() == reflect.Ptr, t.Kind() == reflect.Interface, t.Kind() == reflect.Slice:
+		return reflect.New(t).Interface()
+	case reflect.PtrTo(t).Implements(typScanner):
+		return reflect.New(t).Interface()
+	default:
+		return reflect.New(reflect.PtrTo(t)).Interface()
+	}
+}
+
+// isNullValue reports whether a scanned value is a NULL: an invalid value, a nil
+// interface or pointer, a nil []byte or sql.RawBytes, or a Valuer like sql.NullString
+// whose value is nil
+func isNullValue(v reflect.Value) bool {
+	if !v.IsValid() {
+		return true
+	}
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return true
+		}
+		return isNullValue(v.Elem())
+	case reflect.Ptr:
+		return v.IsNil()
+	case reflect.Slice:
+		return v.IsNil() && v.Type().Elem().Kind() == reflect.Uint8
+	}
+	if v.Type().Implements(typValuer) && v.Kind() == reflect.Struct {
+		dv, err := v.Interface().(driver.Valuer).Value()
+		return err == nil && dv == nil
+	}
+	return false
+}
+
+// coerceNull returns the value a NULL is coerced to: nil for pointers, maps, slices
+// and interfaces, an invalid Null* for scanners, otherwise the zero value unless
+// Options.StrictNull is set
+func coerceNull(toType reflect.Type, opts *Options) (reflect.Value, error) {
+	switch toType.Kind() {
+	case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice:
+		return reflect.Zero(toType), nil
+	}
+	if reflect.PtrTo(toType).Implements(typScanner) {
+		return scanInto(reflect.Value{}, toType)
+	}
+	if opts != nil && opts.StrictNull {
+		return reflect.Value{}, ErrNullDest
+	}
+	return reflect.Zero(toType), nil
+}
+
+// scanInto creates a toType and scans the value into it with its Scan method
+func scanInto(v reflect.Value, toType reflect.Type) (reflect.Value, error) {
+	var src any
+	if v.IsValid() {
+		src = v.Interface()
+	}
+	r := reflect.New(toType)
+	if err := r.Interface().(sql.Scanner).Scan(src); err != nil {
+		return reflect.Value{}, err
+	}
+	return r.Elem(), nil
+}
+
+// coerceDecimalText converts an exact decimal text to a string, *big.Rat or number
+func coerceDecimalText(s string, toType reflect.Type, opts *Options) (reflect.Value, error) {
+	switch {
+	case toType == typAny, toType == typString:
+		return reflect.ValueOf(s), nil
+	case toType == typBigRat:
+		r, ok := new(big.Rat).SetString(s)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("invalid decimal %q", s)
+		}
+		return reflect.ValueOf(r), nil
+	case toType.Kind() == reflect.String:
+		return reflect.ValueOf(s).Convert(toType), nil
+	case isFloatKind(toType.Kind()):
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid decimal %q", s)
+		}
+		return convertNumber(reflect.ValueOf(f), toType)
+	case isIntKind(toType.Kind()), isUintKind(toType.Kind()):
+		r, ok := new(big.Rat).SetString(s)
+		if ok && r.IsInt() && r.Num().IsInt64() {
+			return convertNumber(reflect.ValueOf(r.Num().Int64()), toType)
+		}
+		if ok && r.IsInt() && r.Num().IsUint64() {
+			return convertNumber(reflect.ValueOf(r.Num().Uint64()), toType)
+		}
+		return reflect.Value{}, fmt.Errorf("decimal %q can't be converted to %s", s, toType)
+	default:
+		return coerceDest(nil, reflect.ValueOf(s), toType, opts)
+	}
+}
+
+func isIntKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isUintKind(k reflect.Kind) bool {
+	return k >= reflect.Uint && k <= reflect.Uintptr
+}
+
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	return isIntKind(k) || isUintKind(k) || isFloatKind(k)
+}
+
+// convertNumber converts between integer, unsigned and float kinds and fails on overflow
+func convertNumber(v reflect.Value, toType reflect.Type) (reflect.Value, error) {
+	fromKind, toKind := v.Kind(), toType.Kind()
+	r := reflect.New(toType).Elem()
+	overflow := func() (reflect.Value, error) {
+		return reflect.Value{}, fmt.Errorf("value %v overflows %s", v.Interface(), toType)
+	}
+	if isFloatKind(fromKind) && (isIntKind(toKind) || isUintKind(toKind)) && v.Float() != math.Trunc(v.Float()) {
+		return reflect.Value{}, fmt.Errorf("value %v is not an integer", v.Interface())
+	}
+	switch {
+	case isIntKind(fromKind):
+		n := v.Int()
+		switch {
+		case isIntKind(toKind):
+			if r.OverflowInt(n) {
+				return overflow()
+			}
+			r.SetInt(n)
+		case isUintKind(toKind):
+			if n < 0 || r.OverflowUint(uint64(n)) {
+				return overflow()
+			}
+			r.SetUint(uint64(n))
+		case isFloatKind(toKind):
+			r.SetFloat(float64(n))
+		default:
+			return reflect.Value{}, fmt.Errorf("can't convert %s to %s", v.Type(), toType)
+		}
+	case isUintKind(fromKind):
+		n := v.Uint()
+		switch {
+		case isIntKind(toKind):
+			if n > math.MaxInt64 || r.OverflowInt(int64(n)) {
+				return overflow()
+			}
+			r.SetInt(int64(n))
+		case isUintKind(toKind):
+			if r.OverflowUint(n) {
+				return overflow()
+			}
+			r.SetUint(n)
+		case isFloatKind(toKind):
+			r.SetFloat(float64(n))
+		default:
+			return reflect.Value{}, fmt.Errorf("can't convert %s to %s", v.Type(), toType)
+		}
+	case isFloatKind(fromKind):
+		f := v.Float()
+		switch {
+		case isIntKind(toKind):
+			if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 || r.OverflowInt(int64(f)) {
+				return overflow()
+			}
+			r.SetInt(int64(f))
+		case isUintKind(toKind):
+			if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 || r.OverflowUint(uint64(f)) {
+				return overflow()
+			}
+			r.SetUint(uint64(f))
+		case isFloatKind(toKind):
+			if r.OverflowFloat(f) {
+				return overflow()
+			}
+			r.SetFloat(f)
 		default:
-			panic("xxx")
+			return reflect.Value{}, fmt.Errorf("can't convert %s to %s", v.Type(), toType)
 		}
 	default:
-		panic("yyyy")
+		return reflect.Value{}, fmt.Errorf("can't convert %s to %s", v.Type(), toType)
 	}
+	return r, nil
 }
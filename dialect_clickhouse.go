@@ -0,0 +1,155 @@
+package zinc
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type clickhouseDialect struct{}
+
+func (d clickhouseDialect) DriverName() string {
+	return "clickhouse"
+}
+
+func (d clickhouseDialect) Quote(s string, _ *Options) string {
+	return quote(s, quoteBack)
+}
+
+func (d clickhouseDialect) CompileNamedQuery(q string, _ *Options) (string, []string, error) {
+	return compileNamedQuery([]byte(q), bindQuestion)
+}
+
+func (d clickhouseDialect) Capabilities() Capabilities {
+	return Capabilities{
+		Returning:       false,
+		Upsert:          UpsertNone,
+		Pagination:      PaginationLimitOffset,
+		Savepoints:      false,
+		MultiStatements: false,
+		MaxPlaceholders: 0,
+		LastInsertId:    false,
+	}
+}
+
+func (d clickhouseDialect) NewDest(_ *sql.ColumnType, _ *Options) any {
+	return new(any)
+}
+
+func (d clickhouseDialect) CoerceDest(ci *sql.ColumnType, scannedVal reflect.Value, toType reflect.Type, opts *Options) (reflect.Value, error) {
+	return clickhouseCoerce(ci, clickhouseBaseType(ci.DatabaseTypeName()), scannedVal.Interface(), toType, opts)
+}
+
+func clickhouseCoerce(ci *sql.ColumnType, typeName string, v any, toType reflect.Type, opts *Options) (reflect.Value, error) {
+	// Nullable(T) may arrive as *T
+	rv := reflect.ValueOf(v)
+	for rv.IsValid() && rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return coerceDest(ci, reflect.Zero(typAny), toType, opts)
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return coerceDest(ci, reflect.Zero(typAny), toType, opts)
+	}
+	v = rv.Interface()
+
+	switch {
+	case strings.HasPrefix(typeName, "Array("):
+		return clickhouseCoerceArray(ci, clickhouseBaseType(typeName[len("Array("):len(typeName)-1]), rv, toType, opts)
+	case strings.HasPrefix(typeName, "Decimal"):
+		// decimal.Decimal and friends, keep the exact text
+		s := fmt.Sprint(v)
+		if isFloatKind(toType.Kind()) {
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return convertNumber(reflect.ValueOf(f), toType)
+		}
+		return coerceDest(ci, reflect.ValueOf(s), toType, opts)
+	case strings.HasPrefix(typeName, "DateTime"), typeName == "Date", typeName == "Date32":
+		if s, ok := v.(string); ok && (toType == typAny || toType == typTime) {
+			t, err := parseTimeText(s, opts)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(t), nil
+		}
+	case typeName == "String", strings.HasPrefix(typeName, "FixedString"):
+		if b, ok := v.([]byte); ok && toType != typBytes {
+			s, ok := b2s(b, opts.TextCharset)
+			if !ok {
+				return reflect.Value{}, errors.New("failed to convert []byte to string")
+			}
+			return coerceDest(ci, reflect.ValueOf(s), toType, opts)
+		}
+	}
+	if toType == typAny || rv.Type() == toType {
+		return rv, nil
+	}
+	if isNumberKind(rv.Kind()) && isNumberKind(toType.Kind()) {
+		return convertNumber(rv, toType)
+	}
+	return coerceDest(ci, rv, toType, opts)
+}
+
+func clickhouseCoerceArray(ci *sql.ColumnType, elemTypeName string, rv reflect.Value, toType reflect.Type, opts *Options) (reflect.Value, error) {
+	if rv.Kind() != reflect.Slice {
+		return reflect.Value{}, fmt.Errorf("can't coerce clickhouse %s to %s", rv.Type(), toType)
+	}
+	if toType == typAny {
+		// keep typed slices, unwrap Nullable elements into []any
+		if rv.Type().Elem().Kind() != reflect.Ptr && !strings.HasPrefix(elemTypeName, "Decimal") {
+			return rv, nil
+		}
+		r := make([]any, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			ev, err := clickhouseCoerce(ci, elemTypeName, rv.Index(i).Interface(), typAny, opts)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			if ev.IsValid() {
+				r = append(r, ev.Interface())
+			} else {
+				r = append(r, nil)
+			}
+		}
+		return reflect.ValueOf(r), nil
+	}
+	if rv.Type() == toType {
+		return rv, nil
+	}
+	if toType.Kind() != reflect.Slice {
+		return reflect.Value{}, fmt.Errorf("can't coerce clickhouse array to %s", toType)
+	}
+	r := reflect.MakeSlice(toType, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		ev, err := clickhouseCoerce(ci, elemTypeName, rv.Index(i).Interface(), toType.Elem(), opts)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		r = reflect.Append(r, ev)
+	}
+	return r, nil
+}
+
+// clickhouseBaseType strips Nullable(...) and LowCardinality(...) wrappers
+func clickhouseBaseType(typeName string) string {
+	typeName = strings.TrimSpace(typeName)
+	for {
+		unwrapped := false
+		for _, wrapper := range []string{"Nullable(", "LowCardinality("} {
+			if strings.HasPrefix(typeName, wrapper) && strings.HasSuffix(typeName, ")") {
+				typeName = typeName[len(wrapper) : len(typeName)-1]
+				unwrapped = true
+			}
+		}
+		if !unwrapped {
+			return typeName
+		}
+	}
+}
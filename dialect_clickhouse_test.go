@@ -0,0 +1,92 @@
+package zinc
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testDecimal string
+
+func (d testDecimal) String() string {
+	return string(d)
+}
+
+func TestClickhouseColumns(t *testing.T) {
+	sqlDB, d := openTestDB(t)
+	db, err := New("clickhouse", sqlDB, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cols := []testColumn{
+		{name: "name", typeName: "LowCardinality(Nullable(String))"},
+		{name: "nick", typeName: "Nullable(String)"},
+		{name: "n", typeName: "Nullable(Int32)"},
+		{name: "tags", typeName: "Array(LowCardinality(Nullable(String)))"},
+		{name: "ids", typeName: "Array(UInt64)"},
+		{name: "price", typeName: "Nullable(Decimal(10, 2))"},
+		{name: "s", typeName: "String"},
+	}
+	str := func(s string) *string { return &s }
+	n := int32(5)
+	d.setRows(cols, []driver.Value{
+		str("a"), (*string)(nil), &n, []*string{str("x"), nil}, []uint64{1, 2}, testDecimal("12.50"), []byte("hi"),
+	})
+
+	var m map[string]any
+	if err := db.RawQueryOne(&m, "SELECT"); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"name":  "a",
+		"nick":  nil,
+		"n":     int32(5),
+		"tags":  []any{"x", nil},
+		"ids":   []uint64{1, 2},
+		"price": "12.50",
+		"s":     "hi",
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Fatalf("m = %#v", m)
+	}
+
+	type row struct {
+		Name  string    `zcol:"name"`
+		Nick  *string   `zcol:"nick"`
+		N     int64     `zcol:"n"`
+		IDs   []int     `zcol:"ids"`
+		Price float64   `zcol:"price"`
+		S     []byte    `zcol:"s"`
+		Tags  []*string `zcol:"tags"`
+	}
+	var r row
+	if err := db.RawQueryOne(&r, "SELECT"); err != nil {
+		t.Fatal(err)
+	}
+	if r.Name != "a" || r.Nick != nil || r.N != 5 || !reflect.DeepEqual(r.IDs, []int{1, 2}) || r.Price != 12.5 || string(r.S) != "hi" ||
+		len(r.Tags) != 2 || *r.Tags[0] != "x" || r.Tags[1] != nil {
+		t.Fatalf("r = %+v", r)
+	}
+
+	d.setRows([]testColumn{{name: "t", typeName: "Nullable(DateTime('UTC'))"}}, []driver.Value{"2024-01-02 03:04:05"})
+	var tm time.Time
+	if err := db.RawQueryOne(&tm, "SELECT"); err != nil || !tm.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Fatalf("tm = %v, %v", tm, err)
+	}
+}
+
+func TestClickhouseBaseType(t *testing.T) {
+	tests := map[string]string{
+		"String":                           "String",
+		"Nullable(Int32)":                  "Int32",
+		"LowCardinality(Nullable(String))": "String",
+		" Nullable(LowCardinality(FixedString(3)))": "FixedString(3)",
+		"Array(Nullable(String))":                   "Array(Nullable(String))",
+	}
+	for typeName, want := range tests {
+		if got := clickhouseBaseType(typeName); got != want {
+			t.Errorf("clickhouseBaseType(%q) = %q, want %q", typeName, got, want)
+		}
+	}
+}
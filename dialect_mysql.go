@@ -2,8 +2,14 @@ package zinc
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
 )
 
 type mysqlDialect struct{}
@@ -20,30 +26,229 @@ func (d mysqlDialect) CompileNamedQuery(q string, _ *Options) (string, []string,
 	return compileNamedQuery([]byte(q), bindQuestion)
 }
 
+func (d mysqlDialect) Capabilities() Capabilities {
+	return Capabilities{
+		Returning:       false,
+		Upsert:          UpsertOnDuplicateKey,
+		Pagination:      PaginationLimitOffset,
+		Savepoints:      true,
+		MultiStatements: false, // needs multiStatements=true in DSN
+		MaxPlaceholders: 65535,
+		LastInsertId:    true,
+	}
+}
+
 func (d mysqlDialect) NewDest(ci *sql.ColumnType, _ *Options) any {
-	return reflect.New(ci.ScanType()).Interface()
+	switch ci.DatabaseTypeName() {
+	case "DATE", "DATETIME", "TIMESTAMP":
+		// the ScanType is sql.NullTime even without parseTime=true, when the driver returns text
+		return new(any)
+	default:
+		return newScanDest(ci)
+	}
 }
 
 func (d mysqlDialect) CoerceDest(ci *sql.ColumnType, scannedVal reflect.Value, toType reflect.Type, opts *Options) (reflect.Value, error) {
-	if toType == typAny {
-		if sliceContains(mysqlTextTypes, ci.DatabaseTypeName()) {
-			switch a := scannedVal.Interface().(type) {
-			case []byte:
-				s, ok := b2s(a, opts.TextCharset)
-				if !ok {
-					return reflect.Value{}, errors.New("failed to convert []byte to string")
-				}
-				return reflect.ValueOf(s), nil
-			case sql.RawBytes:
-				s, ok := b2s(a, opts.TextCharset)
-				if !ok {
-					return reflect.Value{}, errors.New("failed to convert sql.RawBytes to string")
-				}
-				return reflect.ValueOf(s), nil
-			}
-		}
-	}
-	return coerceDest(ci, scannedVal, toType, opts)
+	v, isNull := mysqlValue(scannedVal.Interface())
+	if isNull {
+		return coerceDest(ci, reflect.Zero(typAny), toType, opts)
+	}
+	typeName := ci.DatabaseTypeName()
+	switch typeName {
+	case "DECIMAL":
+		if s, ok := mysqlText(v); ok {
+			return coerceDecimalText(s, toType, opts)
+		}
+	case "JSON":
+		if b, ok := v.([]byte); ok {
+			switch {
+			case toType == typAny, toType == typJSONRawMessage:
+				return reflect.ValueOf(json.RawMessage(cloneSlice(b))), nil
+			case toType == typBytes:
+				return reflect.ValueOf(cloneSlice(b)), nil
+			}
+		}
+	case "BIT":
+		if b, ok := v.([]byte); ok {
+			if len(b) > 8 {
+				return reflect.Value{}, fmt.Errorf("BIT value of %d bytes overflows uint64", len(b))
+			}
+			var n uint64
+			for _, c := range b {
+				n = n<<8 | uint64(c)
+			}
+			if toType.Kind() == reflect.Bool {
+				return reflect.ValueOf(n != 0).Convert(toType), nil
+			}
+			if toType == typAny {
+				return reflect.ValueOf(n), nil
+			}
+			if isNumberKind(toType.Kind()) {
+				return convertNumber(reflect.ValueOf(n), toType)
+			}
+		}
+	case "SET":
+		if toType == typAny || (toType.Kind() == reflect.Slice && toType.Elem().Kind() == reflect.String) {
+			s, err := mysqlString(v, opts)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			items := []string{}
+			if s != "" {
+				items = strings.Split(s, ",")
+			}
+			if toType == typAny {
+				return reflect.ValueOf(items), nil
+			}
+			r := reflect.MakeSlice(toType, 0, len(items))
+			for _, item := range items {
+				r = reflect.Append(r, reflect.ValueOf(item).Convert(toType.Elem()))
+			}
+			return r, nil
+		}
+	case "DATE", "DATETIME", "TIMESTAMP":
+		if s, ok := mysqlText(v); ok && (toType == typAny || toType == typTime) {
+			t, err := parseTimeText(s, opts)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(t), nil
+		}
+	case "TIME":
+		if s, ok := mysqlText(v); ok && (toType == typAny || toType == typDuration) {
+			dur, err := parseDurationText(s)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(dur), nil
+		}
+	case "YEAR":
+		if toType == typAny || isNumberKind(toType.Kind()) {
+			year, err := mysqlYear(v)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			if toType == typAny {
+				return reflect.ValueOf(int(year)), nil
+			}
+			return convertNumber(reflect.ValueOf(year), toType)
+		}
+	}
+	if toType == typAny && (sliceContains(mysqlTextTypes, typeName) || typeName == "ENUM") {
+		if _, ok := v.([]byte); ok {
+			s, err := mysqlString(v, opts)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(s), nil
+		}
+	}
+	return coerceDest(ci, reflect.ValueOf(v), toType, opts)
+}
+
+// mysqlValue unwraps the sql.Null* scan types and copies sql.RawBytes which are reused by the driver
+func mysqlValue(v any) (any, bool) {
+	switch a := v.(type) {
+	case nil:
+		return nil, true
+	case sql.RawBytes:
+		if a == nil {
+			return nil, true
+		}
+		return cloneSlice([]byte(a)), false
+	case sql.NullString:
+		return a.String, !a.Valid
+	case sql.NullInt64:
+		return a.Int64, !a.Valid
+	case sql.NullInt32:
+		return a.Int32, !a.Valid
+	case sql.NullInt16:
+		return a.Int16, !a.Valid
+	case sql.NullByte:
+		return a.Byte, !a.Valid
+	case sql.NullFloat64:
+		return a.Float64, !a.Valid
+	case sql.NullBool:
+		return a.Bool, !a.Valid
+	case sql.NullTime:
+		return a.Time, !a.Valid
+	default:
+		return v, false
+	}
+}
+
+// mysqlYear reads a YEAR value, the driver scans it as int16/uint16, or as text without a ScanType
+func mysqlYear(v any) (int64, error) {
+	if s, ok := mysqlText(v); ok {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid YEAR %q", s)
+		}
+		return n, nil
+	}
+	switch rv := reflect.ValueOf(v); {
+	case isIntKind(rv.Kind()):
+		return rv.Int(), nil
+	case isUintKind(rv.Kind()):
+		return int64(rv.Uint()), nil
+	default:
+		return 0, fmt.Errorf("unsupported YEAR value of type %T", v)
+	}
+}
+
+func mysqlText(v any) (string, bool) {
+	switch a := v.(type) {
+	case []byte:
+		return string(a), true
+	case string:
+		return a, true
+	default:
+		return "", false
+	}
+}
+
+func mysqlString(v any, opts *Options) (string, error) {
+	switch a := v.(type) {
+	case []byte:
+		s, ok := b2s(a, opts.TextCharset)
+		if !ok {
+			return "", errors.New("failed to convert []byte to string")
+		}
+		return s, nil
+	case string:
+		return a, nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+func (d mysqlDialect) Paginate(q string, limit, offset int64, _ *Options) string {
+	return paginateLimitOffset(q, limit, offset, "18446744073709551615")
+}
+
+func (d mysqlDialect) SavepointSQL(name string, opts *Options) string {
+	return "SAVEPOINT " + d.Quote(name, opts)
+}
+
+func (d mysqlDialect) RollbackToSavepointSQL(name string, opts *Options) string {
+	return "ROLLBACK TO SAVEPOINT " + d.Quote(name, opts)
+}
+
+func (d mysqlDialect) ReleaseSavepointSQL(name string, opts *Options) string {
+	return "RELEASE SAVEPOINT " + d.Quote(name, opts)
+}
+
+func (d mysqlDialect) IsRetryableError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return sliceContains(mysqlRetryableErrors, mysqlErr.Number)
+	}
+	return false
+}
+
+var mysqlRetryableErrors = []uint16{
+	1205, // ER_LOCK_WAIT_TIMEOUT
+	1213, // ER_LOCK_DEADLOCK
 }
 
 var mysqlTextTypes = []string{
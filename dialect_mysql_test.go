@@ -0,0 +1,128 @@
+package zinc
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMysqlTimeColumns(t *testing.T) {
+	sqlDB, d := openTestDB(t)
+	loc := time.FixedZone("UTC+8", 8*3600)
+	db, err := New("mysql", sqlDB, &Options{TimeLocation: loc})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// go-sql-driver/mysql reports sql.NullTime whether or not parseTime=true is set
+	nullTime := reflectTypeOf[sql.NullTime]()
+	cols := []testColumn{
+		{name: "d", typeName: "DATE", scanType: nullTime},
+		{name: "dt", typeName: "DATETIME", scanType: nullTime},
+		{name: "tm", typeName: "TIME", scanType: typRawBytes},
+	}
+	want := time.Date(2024, 1, 2, 3, 4, 5, 500000000, loc)
+
+	type row struct {
+		D  time.Time     `zcol:"d"`
+		DT *time.Time    `zcol:"dt"`
+		TM time.Duration `zcol:"tm"`
+	}
+	for _, v := range []driver.Value{[]byte("2024-01-02 03:04:05.5"), want} {
+		d.setRows(cols, []driver.Value{[]byte("2024-01-02"), v, []byte("-01:02:03")})
+		var r row
+		if err := db.RawQueryOne(&r, "SELECT"); err != nil {
+			t.Fatalf("%T: %v", v, err)
+		}
+		if !r.D.Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, loc)) {
+			t.Fatalf("D = %v", r.D)
+		}
+		if r.DT == nil || !r.DT.Equal(want) {
+			t.Fatalf("DT = %v", r.DT)
+		}
+		if r.TM != -(time.Hour + 2*time.Minute + 3*time.Second) {
+			t.Fatalf("TM = %v", r.TM)
+		}
+	}
+
+	d.setRows(cols, []driver.Value{nil, []byte("0000-00-00 00:00:00"), nil})
+	var m map[string]any
+	if err := db.RawQueryOne(&m, "SELECT"); err != nil {
+		t.Fatal(err)
+	}
+	if m["d"] != nil || !m["dt"].(time.Time).IsZero() {
+		t.Fatalf("m = %v", m)
+	}
+}
+
+func TestMysqlColumnTypes(t *testing.T) {
+	sqlDB, d := openTestDB(t)
+	db, err := New("mysql", sqlDB, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cols := []testColumn{
+		{name: "dec", typeName: "DECIMAL", scanType: typRawBytes},
+		{name: "js", typeName: "JSON", scanType: typRawBytes},
+		{name: "bit", typeName: "BIT", scanType: typRawBytes},
+		{name: "set", typeName: "SET", scanType: typRawBytes},
+		{name: "enum", typeName: "ENUM", scanType: typRawBytes},
+		{name: "y", typeName: "YEAR", scanType: typInt16},
+		{name: "uy", typeName: "YEAR", scanType: typUint16},
+		{name: "ny", typeName: "YEAR", scanType: reflectTypeOf[sql.NullInt16]()},
+	}
+	d.setRows(cols, []driver.Value{
+		[]byte("12345678901234567890.5"), []byte(`{"a":1}`), []byte{0x01, 0x02}, []byte("a,b"), []byte("x"),
+		int64(2024), int64(2025), int64(2026),
+	})
+
+	var m map[string]any
+	if err := db.RawQueryOne(&m, "SELECT"); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"dec":  "12345678901234567890.5",
+		"js":   json.RawMessage(`{"a":1}`),
+		"bit":  uint64(0x0102),
+		"set":  []string{"a", "b"},
+		"enum": "x",
+		"y":    2024,
+		"uy":   2025,
+		"ny":   2026,
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Fatalf("m = %#v", m)
+	}
+
+	type row struct {
+		Dec  float64  `zcol:"dec"`
+		JS   []byte   `zcol:"js"`
+		Bit  bool     `zcol:"bit"`
+		Set  []string `zcol:"set"`
+		Enum string   `zcol:"enum"`
+		Y    int      `zcol:"y"`
+		UY   uint16   `zcol:"uy"`
+		NY   *int64   `zcol:"ny"`
+	}
+	var r row
+	if err := db.RawQueryOne(&r, "SELECT"); err != nil {
+		t.Fatal(err)
+	}
+	if r.Dec != 12345678901234567890.5 || string(r.JS) != `{"a":1}` || !r.Bit || len(r.Set) != 2 || r.Enum != "x" ||
+		r.Y != 2024 || r.UY != 2025 || r.NY == nil || *r.NY != 2026 {
+		t.Fatalf("r = %+v", r)
+	}
+
+	var dec int64
+	d.setRows(cols[:1], []driver.Value{[]byte("1.5")})
+	if err := db.RawQueryOne(&dec, "SELECT"); err == nil {
+		t.Fatalf("non-integral DECIMAL into int64 = %d", dec)
+	}
+	var year int
+	d.setRows([]testColumn{{name: "y", typeName: "YEAR"}}, []driver.Value{2024.0})
+	if err := db.RawQueryOne(&year, "SELECT"); err == nil {
+		t.Fatalf("float YEAR = %d", year)
+	}
+}
@@ -0,0 +1,167 @@
+package zinc
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type oracleDialect struct{}
+
+func (d oracleDialect) DriverName() string {
+	return "godror"
+}
+
+func (d oracleDialect) Quote(s string, _ *Options) string {
+	// unquoted identifiers are stored in upper case
+	return quoteWith(s, quoteDouble, strings.ToUpper)
+}
+
+func (d oracleDialect) CompileNamedQuery(q string, _ *Options) (string, []string, error) {
+	// oracle only supports named type bind vars
+	return compileNamedQuery([]byte(q), bindNamed)
+}
+
+func (d oracleDialect) Capabilities() Capabilities {
+	return Capabilities{
+		Returning:       false, // RETURNING INTO needs out binds
+		Upsert:          UpsertMerge,
+		Pagination:      PaginationOffsetFetch,
+		Savepoints:      true,
+		MultiStatements: false,
+		MaxPlaceholders: 65535,
+		LastInsertId:    false,
+	}
+}
+
+func (d oracleDialect) NativeNamedBind() bool {
+	return true
+}
+
+func (d oracleDialect) Rebind(q string, _ *Options) string {
+	return rebindQuestion(q, bindNamed)
+}
+
+func (d oracleDialect) Paginate(q string, limit, offset int64, _ *Options) string {
+	q = strings.TrimRight(q, " \t\r\n;")
+	if offset > 0 {
+		q += " OFFSET " + strconv.FormatInt(offset, 10) + " ROWS"
+	}
+	if limit > 0 {
+		q += " FETCH NEXT " + strconv.FormatInt(limit, 10) + " ROWS ONLY"
+	}
+	return q
+}
+
+func (d oracleDialect) NewDest(_ *sql.ColumnType, _ *Options) any {
+	return new(any)
+}
+
+func (d oracleDialect) CoerceDest(ci *sql.ColumnType, scannedVal reflect.Value, toType reflect.Type, opts *Options) (reflect.Value, error) {
+	v := scannedVal.Interface()
+	if v == nil {
+		return coerceDest(ci, scannedVal, toType, opts)
+	}
+	rv := reflect.ValueOf(v)
+	switch strings.ToUpper(ci.DatabaseTypeName()) {
+	case "NUMBER", "FLOAT", "BINARY_FLOAT", "BINARY_DOUBLE":
+		// godror.Number is a string type holding the exact decimal
+		if rv.Kind() == reflect.String {
+			s := rv.String()
+			switch {
+			case toType == typAny, toType.Kind() == reflect.String:
+				return coerceDest(ci, reflect.ValueOf(s), toType, opts)
+			case isIntKind(toType.Kind()) || isUintKind(toType.Kind()):
+				if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+					return convertNumber(reflect.ValueOf(n), toType)
+				}
+				if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+					return convertNumber(reflect.ValueOf(n), toType)
+				}
+				return reflect.Value{}, fmt.Errorf("can't convert NUMBER %s to %s", s, toType)
+			case isFloatKind(toType.Kind()):
+				f, err := strconv.ParseFloat(s, 64)
+				if err != nil {
+					return reflect.Value{}, err
+				}
+				return convertNumber(reflect.ValueOf(f), toType)
+			}
+		} else if isNumberKind(rv.Kind()) && isNumberKind(toType.Kind()) {
+			return convertNumber(rv, toType)
+		}
+	case "DATE", "TIMESTAMP", "TIMESTAMP WITH TIME ZONE", "TIMESTAMP WITH LOCAL TIME ZONE":
+		if s, ok := v.(string); ok && (toType == typAny || toType == typTime) {
+			t, err := parseOracleTime(s, opts)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(t), nil
+		}
+	case "CLOB", "NCLOB":
+		if r, ok := v.(io.Reader); ok {
+			b, err := io.ReadAll(r)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			v = b
+		}
+		if b, ok := v.([]byte); ok && toType != typBytes {
+			s, ok := b2s(b, opts.TextCharset)
+			if !ok {
+				return reflect.Value{}, errors.New("failed to convert []byte to string")
+			}
+			return coerceDest(ci, reflect.ValueOf(s), toType, opts)
+		}
+		return coerceDest(ci, reflect.ValueOf(v), toType, opts)
+	case "RAW", "LONG RAW", "BLOB":
+		if r, ok := v.(io.Reader); ok {
+			b, err := io.ReadAll(r)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return coerceDest(ci, reflect.ValueOf(b), toType, opts)
+		}
+	}
+	return coerceDest(ci, scannedVal, toType, opts)
+}
+
+func (d oracleDialect) SavepointSQL(name string, opts *Options) string {
+	return "SAVEPOINT " + d.Quote(name, opts)
+}
+
+func (d oracleDialect) RollbackToSavepointSQL(name string, opts *Options) string {
+	return "ROLLBACK TO SAVEPOINT " + d.Quote(name, opts)
+}
+
+func (d oracleDialect) ReleaseSavepointSQL(_ string, _ *Options) string {
+	// no release in oracle
+	return ""
+}
+
+func (d oracleDialect) IsRetryableError(err error) bool {
+	var codeErr interface{ Code() int }
+	if errors.As(err, &codeErr) {
+		return codeErr.Code() == 60 // ORA-00060 deadlock detected
+	}
+	return false
+}
+
+var oracleTimeLayouts = []string{
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999 -07:00",
+}
+
+// parseOracleTime parses zoned text as is, DATE and TIMESTAMP text in Options.TimeLocation
+func parseOracleTime(s string, opts *Options) (time.Time, error) {
+	for _, layout := range oracleTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return parseTimeText(s, opts)
+}
@@ -0,0 +1,60 @@
+package zinc
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+)
+
+func TestOracleQuote(t *testing.T) {
+	d := oracleDialect{}
+	tests := []struct {
+		s, want string
+	}{
+		{"users", `"USERS"`},
+		{"app.users", `"APP"."USERS"`},
+		{`"MixedCase".col`, `"MixedCase"."COL"`},
+		{`a"b`, `"A""B"`},
+		{"a..b", ""},
+	}
+	for _, tt := range tests {
+		if got := d.Quote(tt.s, nil); got != tt.want {
+			t.Errorf("Quote(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestOracleBind(t *testing.T) {
+	db := newBindTestDB(t, "godror")
+	tests := []struct {
+		q       string
+		args    []any
+		bound   string
+		wantArg []any
+	}{
+		{"SELECT * FROM t WHERE a = ? AND b = ?", []any{1, 2}, "SELECT * FROM t WHERE a = :1 AND b = :2", []any{1, 2}},
+		{"SELECT * FROM t WHERE id IN (?) AND c = ?", []any{[]int{1, 2}, 3}, "SELECT * FROM t WHERE id IN (:1, :2) AND c = :3", []any{1, 2, 3}},
+		// named args are bound natively by name, once per name
+		{"SELECT * FROM t WHERE a = :a OR b = :a", []any{map[string]any{"a": 1}}, "SELECT * FROM t WHERE a = :a OR b = :a", []any{sql.Named("a", 1)}},
+	}
+	for _, tt := range tests {
+		uArgs, _ := United(tt.args...)
+		bound, boundArgs, err := db.Bind(tt.q, uArgs)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.q, err)
+		}
+		if bound != tt.bound || !reflect.DeepEqual(boundArgs, tt.wantArg) {
+			t.Errorf("Bind(%q) = %q %#v", tt.q, bound, boundArgs)
+		}
+	}
+}
+
+func TestOraclePaginate(t *testing.T) {
+	d := oracleDialect{}
+	if got, want := d.Paginate("SELECT * FROM t ORDER BY id;", 10, 20, nil), "SELECT * FROM t ORDER BY id OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY"; got != want {
+		t.Errorf("Paginate = %q", got)
+	}
+	if got, want := d.Paginate("SELECT * FROM t", 10, 0, nil), "SELECT * FROM t FETCH NEXT 10 ROWS ONLY"; got != want {
+		t.Errorf("Paginate = %q", got)
+	}
+}
@@ -0,0 +1,313 @@
+package zinc
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type postgresDialect struct{}
+
+func (d postgresDialect) DriverName() string {
+	return "postgres"
+}
+
+func (d postgresDialect) Quote(s string, _ *Options) string {
+	return quote(s, quoteDouble)
+}
+
+func (d postgresDialect) CompileNamedQuery(q string, _ *Options) (string, []string, error) {
+	// compile to '?' first, IN expansion and Rebind turn them into $n later
+	return compileNamedQueryWithCasts(q)
+}
+
+func (d postgresDialect) Capabilities() Capabilities {
+	return Capabilities{
+		Returning:       true,
+		Upsert:          UpsertOnConflict,
+		Pagination:      PaginationLimitOffset,
+		Savepoints:      true,
+		MultiStatements: false,
+		MaxPlaceholders: 65535,
+		LastInsertId:    false,
+	}
+}
+
+// Rebind rewrites '?' to $n outside literals, comments and dollar-quoted bodies, write '??' for
+// a literal '?' such as the jsonb operators ?, ?| and ?&
+func (d postgresDialect) Rebind(q string, _ *Options) string {
+	return rebindQuestion(q, bindDollar)
+}
+
+func (d postgresDialect) NewDest(_ *sql.ColumnType, _ *Options) any {
+	// drivers disagree on scan types (lib/pq vs pgx), take the raw driver value
+	return new(any)
+}
+
+func (d postgresDialect) CoerceDest(ci *sql.ColumnType, scannedVal reflect.Value, toType reflect.Type, opts *Options) (reflect.Value, error) {
+	v := scannedVal.Interface()
+	if v == nil {
+		return coerceDest(ci, scannedVal, toType, opts)
+	}
+	typeName := strings.ToUpper(ci.DatabaseTypeName())
+	if strings.HasPrefix(typeName, "_") {
+		return postgresCoerceArray(typeName[1:], v, toType, opts)
+	}
+	normalized, err := postgresNormalize(typeName, v, opts)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	nv := reflect.ValueOf(normalized)
+	if toType == typAny || nv.Type() == toType {
+		return nv, nil
+	}
+	return coerceDest(ci, nv, toType, opts)
+}
+
+func (d postgresDialect) IsRetryableError(err error) bool {
+	// both lib/pq and pgx errors expose SQLState()
+	var stateErr interface{ SQLState() string }
+	if errors.As(err, &stateErr) {
+		return sliceContains(postgresRetryableStates, stateErr.SQLState())
+	}
+	return false
+}
+
+var postgresRetryableStates = []string{
+	"40001", // serialization_failure
+	"40P01", // deadlock_detected
+}
+
+func postgresNormalize(typeName string, v any, opts *Options) (any, error) {
+	switch typeName {
+	case "BYTEA":
+		// drivers decode bytea to []byte, only text such as array elements is still hex encoded
+		switch a := v.(type) {
+		case []byte:
+			return a, nil
+		case string:
+			if strings.HasPrefix(a, `\x`) {
+				return hex.DecodeString(a[2:])
+			}
+		}
+	case "NUMERIC", "DECIMAL":
+		if s, ok := postgresText(v); ok {
+			return s, nil
+		}
+	case "JSON", "JSONB":
+		switch a := v.(type) {
+		case []byte:
+			return json.RawMessage(a), nil
+		case string:
+			return json.RawMessage(a), nil
+		}
+	case "UUID":
+		switch a := v.(type) {
+		case [16]byte:
+			return formatUUID(a[:]), nil
+		case []byte:
+			if len(a) == 16 {
+				return formatUUID(a), nil
+			}
+			return string(a), nil
+		}
+	case "TIMESTAMPTZ", "TIMESTAMP", "DATE":
+		if s, ok := postgresText(v); ok {
+			return parsePostgresTime(s, opts)
+		}
+	case "CHAR", "BPCHAR", "VARCHAR", "TEXT", "NAME", "CITEXT":
+		if b, ok := v.([]byte); ok {
+			s, ok := b2s(b, opts.TextCharset)
+			if !ok {
+				return nil, errors.New("failed to convert []byte to string")
+			}
+			return s, nil
+		}
+	}
+	return v, nil
+}
+
+func postgresCoerceArray(elemTypeName string, v any, toType reflect.Type, opts *Options) (reflect.Value, error) {
+	s, ok := postgresText(v)
+	if !ok {
+		// the driver already decoded the array
+		rv := reflect.ValueOf(v)
+		if toType == typAny || rv.Type() == toType {
+			return rv, nil
+		}
+		return reflect.Value{}, fmt.Errorf("can't coerce %s to %s", rv.Type(), toType)
+	}
+	elems, err := parsePostgresArray(s)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if toType == typAny {
+		r := make([]any, 0, len(elems))
+		for _, elem := range elems {
+			if elem == nil {
+				r = append(r, nil)
+				continue
+			}
+			ev, err := postgresNormalizeElem(elemTypeName, *elem, opts)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			r = append(r, ev)
+		}
+		return reflect.ValueOf(r), nil
+	}
+	if toType.Kind() != reflect.Slice {
+		return reflect.Value{}, fmt.Errorf("can't coerce postgres array to %s", toType)
+	}
+	r := reflect.MakeSlice(toType, 0, len(elems))
+	for i, elem := range elems {
+		if elem == nil {
+			return reflect.Value{}, fmt.Errorf("NULL element #%d in postgres array", i)
+		}
+		ev, err := postgresNormalizeElem(elemTypeName, *elem, opts)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		targetVal, err := coerceDest(nil, reflect.ValueOf(ev), toType.Elem(), opts)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		r = reflect.Append(r, targetVal)
+	}
+	return r, nil
+}
+
+func postgresNormalizeElem(elemTypeName string, s string, opts *Options) (any, error) {
+	switch elemTypeName {
+	case "INT2", "INT4", "INT8":
+		return strconv.ParseInt(s, 10, 64)
+	case "FLOAT4", "FLOAT8":
+		return strconv.ParseFloat(s, 64)
+	case "BOOL":
+		return s == "t" || s == "true", nil
+	default:
+		return postgresNormalize(elemTypeName, s, opts)
+	}
+}
+
+func postgresText(v any) (string, bool) {
+	switch a := v.(type) {
+	case []byte:
+		return string(a), true
+	case string:
+		return a, true
+	default:
+		return "", false
+	}
+}
+
+// parsePostgresArray parses the text form of a one-dimensional array like {1,"a b",NULL}
+func parsePostgresArray(s string) ([]*string, error) {
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, fmt.Errorf("invalid postgres array %q", s)
+	}
+	body := s[1 : len(s)-1]
+	if body == "" {
+		return []*string{}, nil
+	}
+	var elems []*string
+	var buf strings.Builder
+	quoted, inQuote, escaped := false, false, false
+	flush := func() {
+		e := buf.String()
+		buf.Reset()
+		if !quoted && e == "NULL" {
+			elems = append(elems, nil)
+		} else {
+			elems = append(elems, &e)
+		}
+		quoted = false
+	}
+	for i := 0; i < len(body); i++ {
+		b := body[i]
+		switch {
+		case escaped:
+			buf.WriteByte(b)
+			escaped = false
+		case b == '\\':
+			escaped = true
+		case b == '"':
+			inQuote = !inQuote
+			quoted = true
+		case b == '{' && !inQuote:
+			return nil, errors.New("multi-dimensional postgres arrays are not supported")
+		case b == ',' && !inQuote:
+			flush()
+		default:
+			buf.WriteByte(b)
+		}
+	}
+	if inQuote || escaped {
+		return nil, fmt.Errorf("invalid postgres array %q", s)
+	}
+	flush()
+	return elems, nil
+}
+
+var postgresTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999Z07:00:00",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02T15:04:05.999999999Z07:00",
+}
+
+// parsePostgresTime parses TIMESTAMPTZ text as is, TIMESTAMP and DATE text in Options.TimeLocation
+func parsePostgresTime(s string, opts *Options) (time.Time, error) {
+	for _, layout := range postgresTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return parseTimeText(s, opts)
+}
+
+func formatUUID(b []byte) string {
+	h := hex.EncodeToString(b)
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:32]
+}
+
+// compileNamedQueryWithCasts is like compileNamedQuery with bindQuestion, but keeps
+// '::' type casts and ignores ':' inside quoted literals, comments and dollar-quoted bodies
+func compileNamedQueryWithCasts(q string) (string, []string, error) {
+	names := make([]string, 0, 10)
+	rebound := make([]byte, 0, len(q))
+	isNameRune := func(b byte) bool {
+		return unicode.IsOneOf(allowedBindRunes, rune(b)) || b == '_' || b == '.'
+	}
+	for i := 0; i < len(q); i++ {
+		if j := skipNonCode(q, i); j > i {
+			rebound = append(rebound, q[i:j]...)
+			i = j - 1
+			continue
+		}
+		b := q[i]
+		switch {
+		case b == ':' && i+1 < len(q) && q[i+1] == ':':
+			rebound = append(rebound, ':', ':')
+			i++
+		case b == ':' && i+1 < len(q) && isNameRune(q[i+1]):
+			j := i + 1
+			for j < len(q) && isNameRune(q[j]) {
+				j++
+			}
+			names = append(names, q[i+1:j])
+			rebound = append(rebound, '?')
+			i = j - 1
+		default:
+			rebound = append(rebound, b)
+		}
+	}
+	return string(rebound), names, nil
+}
@@ -0,0 +1,98 @@
+package zinc
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+)
+
+func TestCompileNamedQueryWithCasts(t *testing.T) {
+	cases := []struct {
+		q, want string
+		names   []string
+	}{
+		{"SELECT :a::int, :b", "SELECT ?::int, ?", []string{"a", "b"}},
+		{"SELECT ':a', \":b\", :c", "SELECT ':a', \":b\", ?", []string{"c"}},
+		{"SELECT :a -- :b\n, /* :c */ $$ :d $$, :e", "SELECT ? -- :b\n, /* :c */ $$ :d $$, ?", []string{"a", "e"}},
+		{"SELECT now()::date, :t.x", "SELECT now()::date, ?", []string{"t.x"}},
+	}
+	for _, c := range cases {
+		got, names, err := compileNamedQueryWithCasts(c.q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c.want || !reflect.DeepEqual(names, c.names) {
+			t.Errorf("compileNamedQueryWithCasts(%q) = %q %v, want %q %v", c.q, got, names, c.want, c.names)
+		}
+	}
+}
+
+func TestParsePostgresArray(t *testing.T) {
+	str := func(s string) *string { return &s }
+	tests := []struct {
+		s    string
+		want []*string
+	}{
+		{"{}", []*string{}},
+		{"{1,2,3}", []*string{str("1"), str("2"), str("3")}},
+		{`{a,NULL,"NULL",""}`, []*string{str("a"), nil, str("NULL"), str("")}},
+		{`{"a,b","c\"d","e\\f"}`, []*string{str("a,b"), str(`c"d`), str(`e\f`)}},
+		{`{"{x}"}`, []*string{str("{x}")}},
+	}
+	for _, tt := range tests {
+		got, err := parsePostgresArray(tt.s)
+		if err != nil {
+			t.Errorf("parsePostgresArray(%q): %v", tt.s, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parsePostgresArray(%q) = %v", tt.s, got)
+		}
+	}
+	for _, s := range []string{"", "1,2", "{1,2", `{"a}`, `{a\}`, "{{1,2},{3,4}}"} {
+		if got, err := parsePostgresArray(s); err == nil {
+			t.Errorf("parsePostgresArray(%q) = %v, want error", s, got)
+		}
+	}
+}
+
+func TestPostgresBytea(t *testing.T) {
+	sqlDB, d := openTestDB(t)
+	db, err := New("postgres", sqlDB, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cols := []testColumn{{name: "b", typeName: "BYTEA"}}
+	tests := []struct {
+		v    driver.Value
+		want []byte
+	}{
+		// drivers already decode bytea, binary data that looks like hex is kept
+		{[]byte(`\x41`), []byte(`\x41`)},
+		{[]byte{0xff, 0x00}, []byte{0xff, 0x00}},
+		{`\x41ff`, []byte{0x41, 0xff}},
+	}
+	for _, tt := range tests {
+		d.setRows(cols, []driver.Value{tt.v})
+		var b []byte
+		if err := db.RawQueryOne(&b, "SELECT"); err != nil {
+			t.Fatalf("%q: %v", tt.v, err)
+		}
+		if !bytes.Equal(b, tt.want) {
+			t.Errorf("%q = %q, want %q", tt.v, b, tt.want)
+		}
+	}
+
+	d.setRows([]testColumn{{name: "bs", typeName: "_BYTEA"}}, []driver.Value{[]byte(`{"\\x41","\\x4142"}`)})
+	// array elements are text and still hex encoded
+	var r struct {
+		BS [][]byte `zcol:"bs"`
+	}
+	if err := db.RawQueryOne(&r, "SELECT"); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.BS) != 2 || string(r.BS[0]) != "A" || string(r.BS[1]) != "AB" {
+		t.Fatalf("bs = %q", r.BS)
+	}
+}
@@ -0,0 +1,123 @@
+package zinc
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var (
+	dialects = map[string]Dialect{
+		"mysql":      mysqlDialect{},
+		"postgres":   postgresDialect{},
+		"pgx":        postgresDialect{},
+		"pgx/v5":     postgresDialect{},
+		"sqlite3":    sqliteDialect{},
+		"sqlite":     sqliteDialect{},
+		"sqlserver":  sqlserverDialect{},
+		"mssql":      sqlserverDialect{},
+		"godror":     oracleDialect{},
+		"oracle":     oracleDialect{},
+		"clickhouse": clickhouseDialect{},
+	}
+	dialectAliases = map[string]string{}
+	dialectsMutex  = sync.RWMutex{}
+)
+
+// driver package path prefix -> driver name, used when the driver name is unknown
+var driverPackages = []struct {
+	pkgPath    string
+	driverName string
+}{
+	{"github.com/go-sql-driver/mysql", "mysql"},
+	{"github.com/lib/pq", "postgres"},
+	{"github.com/jackc/pgx", "pgx"},
+	{"github.com/mattn/go-sqlite3", "sqlite3"},
+	{"modernc.org/sqlite", "sqlite"},
+	{"github.com/microsoft/go-mssqldb", "sqlserver"},
+	{"github.com/denisenkom/go-mssqldb", "sqlserver"},
+	{"github.com/godror/godror", "godror"},
+	{"github.com/sijms/go-ora", "oracle"},
+	{"github.com/ClickHouse/clickhouse-go", "clickhouse"},
+}
+
+func RegisterDialect(driverName string, d Dialect) {
+	lockW(&dialectsMutex, func() {
+		if d == nil {
+			delete(dialects, driverName)
+		} else {
+			dialects[driverName] = d
+		}
+	})
+}
+
+func RegisterDialectAlias(alias string, driverName string) {
+	lockW(&dialectsMutex, func() {
+		if driverName == "" {
+			delete(dialectAliases, alias)
+		} else {
+			dialectAliases[alias] = driverName
+		}
+	})
+}
+
+func LookupDialect(driverName string) Dialect {
+	return dialectOf(driverName)
+}
+
+func dialectOf(driverName string) Dialect {
+	var d Dialect
+	lockR(&dialectsMutex, func() {
+		// follow alias chains, bounded to avoid cycles
+		for i := 0; i <= len(dialectAliases); i++ {
+			if d = dialects[driverName]; d != nil {
+				return
+			}
+			target, ok := dialectAliases[driverName]
+			if !ok {
+				return
+			}
+			driverName = target
+		}
+	})
+	return d
+}
+
+func detectDialect(db *sql.DB) Dialect {
+	if db == nil {
+		return nil
+	}
+	return detectDialectByDriver(reflect.ValueOf(db.Driver()), 3)
+}
+
+func detectDialectByDriver(v reflect.Value, depth int) Dialect {
+	for v.IsValid() && (v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr) {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	pkgPath := v.Type().PkgPath()
+	for _, dp := range driverPackages {
+		if pkgPath == dp.pkgPath || strings.HasPrefix(pkgPath, dp.pkgPath+"/") {
+			return dialectOf(dp.driverName)
+		}
+	}
+	// instrumented drivers usually wrap the original driver in a field
+	if depth <= 0 || v.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		if f.Kind() == reflect.Interface && f.Type().Implements(typDriver) {
+			if d := detectDialectByDriver(f, depth-1); d != nil {
+				return d
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package zinc
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// wrappedDriver wraps another driver like instrumentation packages do
+type wrappedDriver struct {
+	driver.Driver
+}
+
+// doubleWrappedDriver keeps the wrapped driver in a named field
+type doubleWrappedDriver struct {
+	name   string
+	parent driver.Driver
+}
+
+func (d doubleWrappedDriver) Open(dsn string) (driver.Conn, error) {
+	return d.parent.Open(dsn)
+}
+
+func init() {
+	sql.Register("mysql-wrapped", wrappedDriver{mysql.MySQLDriver{}})
+	sql.Register("mysql-double-wrapped", doubleWrappedDriver{name: "x", parent: wrappedDriver{mysql.MySQLDriver{}}})
+}
+
+func TestRegisterDialect(t *testing.T) {
+	RegisterDialect("custom-db", sqliteDialect{})
+	t.Cleanup(func() { RegisterDialect("custom-db", nil) })
+	if d := LookupDialect("custom-db"); d != (sqliteDialect{}) {
+		t.Fatalf("custom-db = %v", d)
+	}
+	RegisterDialect("custom-db", nil)
+	if d := LookupDialect("custom-db"); d != nil {
+		t.Fatalf("custom-db after removal = %v", d)
+	}
+}
+
+func TestRegisterDialectAlias(t *testing.T) {
+	RegisterDialectAlias("alias-1", "alias-2")
+	RegisterDialectAlias("alias-2", "postgres")
+	RegisterDialectAlias("cycle-1", "cycle-2")
+	RegisterDialectAlias("cycle-2", "cycle-1")
+	t.Cleanup(func() {
+		for _, alias := range []string{"alias-1", "alias-2", "cycle-1", "cycle-2"} {
+			RegisterDialectAlias(alias, "")
+		}
+	})
+	if d := LookupDialect("alias-1"); d != (postgresDialect{}) {
+		t.Fatalf("alias chain = %v", d)
+	}
+	// a registered dialect wins over an alias of the same name
+	RegisterDialectAlias("mysql", "postgres")
+	t.Cleanup(func() { RegisterDialectAlias("mysql", "") })
+	if d := LookupDialect("mysql"); d != (mysqlDialect{}) {
+		t.Fatalf("mysql = %v", d)
+	}
+	if d := LookupDialect("cycle-1"); d != nil {
+		t.Fatalf("alias cycle = %v", d)
+	}
+	RegisterDialectAlias("alias-2", "")
+	if d := LookupDialect("alias-1"); d != nil {
+		t.Fatalf("broken alias chain = %v", d)
+	}
+}
+
+func TestDetectDialectByDriver(t *testing.T) {
+	for _, driverName := range []string{"mysql-wrapped", "mysql-double-wrapped"} {
+		sqlDB, err := sql.Open(driverName, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		db, err := New(driverName, sqlDB, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", driverName, err)
+		}
+		if db.Dialect() != (mysqlDialect{}) {
+			t.Fatalf("%s: dialect = %v", driverName, db.Dialect())
+		}
+		_ = sqlDB.Close()
+	}
+
+	// the test driver lives in this package, nothing to detect
+	sqlDB, _ := openTestDB(t)
+	if _, err := New("zinctest", sqlDB, nil); !errors.Is(err, ErrUnsupportedDriver) {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+package zinc
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type sqliteDialect struct{}
+
+func (d sqliteDialect) DriverName() string {
+	return "sqlite3"
+}
+
+func (d sqliteDialect) Quote(s string, _ *Options) string {
+	return quote(s, quoteDouble)
+}
+
+func (d sqliteDialect) CompileNamedQuery(q string, _ *Options) (string, []string, error) {
+	return compileNamedQuery([]byte(q), bindQuestion)
+}
+
+func (d sqliteDialect) Capabilities() Capabilities {
+	return Capabilities{
+		Returning:       true, // since 3.35
+		Upsert:          UpsertOnConflict,
+		Pagination:      PaginationLimitOffset,
+		Savepoints:      true,
+		MultiStatements: false,
+		MaxPlaceholders: 32766, // since 3.32, 999 before
+		LastInsertId:    true,
+	}
+}
+
+func (d sqliteDialect) Paginate(q string, limit, offset int64, _ *Options) string {
+	return paginateLimitOffset(q, limit, offset, "-1")
+}
+
+func (d sqliteDialect) NewDest(_ *sql.ColumnType, _ *Options) any {
+	// dynamic typing, ScanType is unreliable, take the raw driver value
+	return new(any)
+}
+
+func (d sqliteDialect) CoerceDest(ci *sql.ColumnType, scannedVal reflect.Value, toType reflect.Type, opts *Options) (reflect.Value, error) {
+	v := scannedVal.Interface()
+	if v == nil {
+		return coerceDest(ci, scannedVal, toType, opts)
+	}
+	declType := strings.ToUpper(ci.DatabaseTypeName())
+	if b, ok := v.([]byte); ok && sqliteAffinity(declType) == sqliteText {
+		s, ok := b2s(b, opts.TextCharset)
+		if !ok {
+			return reflect.Value{}, errors.New("failed to convert []byte to string")
+		}
+		v = s
+	}
+	if sqliteIsTimeType(declType) {
+		if s, ok := v.(string); ok {
+			t, err := parseTimeText(s, opts)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			v = t
+		}
+	}
+
+	rv := reflect.ValueOf(v)
+	if toType == typAny || rv.Type() == toType {
+		return rv, nil
+	}
+	switch {
+	case toType == typTime:
+		switch a := v.(type) {
+		case string:
+			t, err := parseTimeText(a, opts)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(t), nil
+		case int64:
+			return reflect.ValueOf(time.Unix(a, 0).UTC()), nil
+		}
+	case toType.Kind() == reflect.Bool:
+		switch a := v.(type) {
+		case int64:
+			return reflect.ValueOf(a != 0).Convert(toType), nil
+		case string:
+			b, err := strconv.ParseBool(a)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return reflect.ValueOf(b).Convert(toType), nil
+		}
+	case isNumberKind(toType.Kind()):
+		switch a := v.(type) {
+		case int64, float64:
+			return convertNumber(rv, toType)
+		case string:
+			if n, err := strconv.ParseInt(a, 10, 64); err == nil {
+				return convertNumber(reflect.ValueOf(n), toType)
+			}
+			f, err := strconv.ParseFloat(a, 64)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("can't convert %q to %s", a, toType)
+			}
+			return convertNumber(reflect.ValueOf(f), toType)
+		}
+	case toType.Kind() == reflect.String:
+		switch a := v.(type) {
+		case string:
+			return reflect.ValueOf(a).Convert(toType), nil
+		case int64:
+			return reflect.ValueOf(strconv.FormatInt(a, 10)).Convert(toType), nil
+		case float64:
+			return reflect.ValueOf(strconv.FormatFloat(a, 'g', -1, 64)).Convert(toType), nil
+		}
+	case toType == typBytes:
+		if s, ok := v.(string); ok {
+			return reflect.ValueOf([]byte(s)), nil
+		}
+	}
+	return coerceDest(ci, rv, toType, opts)
+}
+
+const (
+	sqliteNumeric = iota
+	sqliteInteger
+	sqliteText
+	sqliteBlob
+	sqliteReal
+)
+
+// sqliteAffinity determines the column affinity from the declared type, see https://www.sqlite.org/datatype3.html
+func sqliteAffinity(declType string) int {
+	switch {
+	case strings.Contains(declType, "INT"):
+		return sqliteInteger
+	case strings.Contains(declType, "CHAR"), strings.Contains(declType, "CLOB"), strings.Contains(declType, "TEXT"):
+		return sqliteText
+	case declType == "", strings.Contains(declType, "BLOB"):
+		return sqliteBlob
+	case strings.Contains(declType, "REAL"), strings.Contains(declType, "FLOA"), strings.Contains(declType, "DOUB"):
+		return sqliteReal
+	default:
+		return sqliteNumeric
+	}
+}
+
+func sqliteIsTimeType(declType string) bool {
+	switch declType {
+	case "DATE", "DATETIME", "TIMESTAMP":
+		return true
+	default:
+		return false
+	}
+}
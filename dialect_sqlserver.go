@@ -0,0 +1,188 @@
+package zinc
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type sqlserverDialect struct{}
+
+func (d sqlserverDialect) DriverName() string {
+	return "sqlserver"
+}
+
+func (d sqlserverDialect) Quote(s string, _ *Options) string {
+	return quote(s, quoteBracket)
+}
+
+func (d sqlserverDialect) CompileNamedQuery(q string, _ *Options) (string, []string, error) {
+	// compile to '?' first, IN expansion and Rebind turn them into @pN later
+	return compileNamedQuery([]byte(q), bindQuestion)
+}
+
+func (d sqlserverDialect) Capabilities() Capabilities {
+	return Capabilities{
+		Returning:       false, // OUTPUT instead
+		Upsert:          UpsertMerge,
+		Pagination:      PaginationOffsetFetch,
+		Savepoints:      true,
+		MultiStatements: true,
+		MaxPlaceholders: 2100,
+		LastInsertId:    false,
+	}
+}
+
+func (d sqlserverDialect) Rebind(q string, _ *Options) string {
+	return rebindQuestion(q, bindAt)
+}
+
+func (d sqlserverDialect) Paginate(q string, limit, offset int64, _ *Options) string {
+	q = strings.TrimRight(q, " \t\r\n;")
+	if limit <= 0 && offset <= 0 {
+		return q
+	}
+	// OFFSET ... FETCH requires ORDER BY
+	if !sqlserverOrderByRegexp.MatchString(topLevelSQL(q)) {
+		q += " ORDER BY (SELECT NULL)"
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	q += " OFFSET " + strconv.FormatInt(offset, 10) + " ROWS"
+	if limit > 0 {
+		q += " FETCH NEXT " + strconv.FormatInt(limit, 10) + " ROWS ONLY"
+	}
+	return q
+}
+
+var sqlserverOrderByRegexp = regexp.MustCompile(`(?i)\border\s+by\b`)
+
+// topLevelSQL blanks out parenthesized parts, literals, [identifiers] and comments of q, so that
+// an ORDER BY in a subquery or OVER (...) is not taken for the ORDER BY of q
+func topLevelSQL(q string) string {
+	masked := []byte(q)
+	depth := 0
+	for i := 0; i < len(q); {
+		j := skipNonCode(q, i)
+		if j == i && q[i] == '[' {
+			// ']]' is an escaped ']' inside a bracketed identifier
+			for j = i + 1; j < len(q); j++ {
+				if q[j] == ']' {
+					if j+1 < len(q) && q[j+1] == ']' {
+						j++
+						continue
+					}
+					j++
+					break
+				}
+			}
+		}
+		if j > i {
+			for ; i < j; i++ {
+				masked[i] = ' '
+			}
+			continue
+		}
+		switch q[i] {
+		case '(':
+			depth++
+			masked[i] = ' '
+		case ')':
+			if depth > 0 {
+				depth--
+			}
+			masked[i] = ' '
+		default:
+			if depth > 0 {
+				masked[i] = ' '
+			}
+		}
+		i++
+	}
+	return string(masked)
+}
+
+func (d sqlserverDialect) NewDest(_ *sql.ColumnType, _ *Options) any {
+	return new(any)
+}
+
+func (d sqlserverDialect) CoerceDest(ci *sql.ColumnType, scannedVal reflect.Value, toType reflect.Type, opts *Options) (reflect.Value, error) {
+	v := scannedVal.Interface()
+	if v == nil {
+		return coerceDest(ci, scannedVal, toType, opts)
+	}
+	switch strings.ToUpper(ci.DatabaseTypeName()) {
+	case "UNIQUEIDENTIFIER":
+		if b, ok := v.([]byte); ok {
+			if toType == typBytes {
+				return reflect.ValueOf(b), nil
+			}
+			s, err := formatSqlserverUUID(b)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return coerceDest(ci, reflect.ValueOf(s), toType, opts)
+		}
+	case "MONEY", "SMALLMONEY", "DECIMAL", "NUMERIC":
+		if b, ok := v.([]byte); ok {
+			// exact decimal text
+			s := string(b)
+			if isFloatKind(toType.Kind()) {
+				f, err := strconv.ParseFloat(s, 64)
+				if err != nil {
+					return reflect.Value{}, err
+				}
+				return convertNumber(reflect.ValueOf(f), toType)
+			}
+			return coerceDest(ci, reflect.ValueOf(s), toType, opts)
+		}
+	case "CHAR", "VARCHAR", "TEXT", "NCHAR", "NVARCHAR", "NTEXT":
+		if b, ok := v.([]byte); ok {
+			s, ok := b2s(b, opts.TextCharset)
+			if !ok {
+				return reflect.Value{}, errors.New("failed to convert []byte to string")
+			}
+			return coerceDest(ci, reflect.ValueOf(s), toType, opts)
+		}
+	}
+	return coerceDest(ci, scannedVal, toType, opts)
+}
+
+func (d sqlserverDialect) SavepointSQL(name string, opts *Options) string {
+	return "SAVE TRANSACTION " + d.Quote(name, opts)
+}
+
+func (d sqlserverDialect) RollbackToSavepointSQL(name string, opts *Options) string {
+	return "ROLLBACK TRANSACTION " + d.Quote(name, opts)
+}
+
+func (d sqlserverDialect) ReleaseSavepointSQL(_ string, _ *Options) string {
+	// no release in sql server
+	return ""
+}
+
+func (d sqlserverDialect) IsRetryableError(err error) bool {
+	var numErr interface{ SQLErrorNumber() int32 }
+	if errors.As(err, &numErr) {
+		return numErr.SQLErrorNumber() == 1205 // deadlock victim
+	}
+	return false
+}
+
+// formatSqlserverUUID formats a uniqueidentifier, whose first three groups are little-endian
+func formatSqlserverUUID(b []byte) (string, error) {
+	if len(b) != 16 {
+		return "", fmt.Errorf("invalid uniqueidentifier length %d", len(b))
+	}
+	u := make([]byte, 16)
+	copy(u, b)
+	u[0], u[1], u[2], u[3] = b[3], b[2], b[1], b[0]
+	u[4], u[5] = b[5], b[4]
+	u[6], u[7] = b[7], b[6]
+	return strings.ToUpper(formatUUID(u)), nil
+}
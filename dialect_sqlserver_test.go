@@ -0,0 +1,36 @@
+package zinc
+
+import (
+	"testing"
+)
+
+func TestSqlserverPaginate(t *testing.T) {
+	d := sqlserverDialect{}
+	tests := []struct {
+		q, want string
+	}{
+		{"SELECT * FROM t ORDER BY id", "SELECT * FROM t ORDER BY id OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY"},
+		{"SELECT * FROM t", "SELECT * FROM t ORDER BY (SELECT NULL) OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY"},
+		{
+			"SELECT * FROM (SELECT TOP 5 * FROM t ORDER BY id) x",
+			"SELECT * FROM (SELECT TOP 5 * FROM t ORDER BY id) x ORDER BY (SELECT NULL) OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY",
+		},
+		{
+			"SELECT ROW_NUMBER() OVER (ORDER BY id) AS n FROM t",
+			"SELECT ROW_NUMBER() OVER (ORDER BY id) AS n FROM t ORDER BY (SELECT NULL) OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY",
+		},
+		{
+			"SELECT 'order by' AS [order by], [a]]order by] -- order by\nFROM t /* order by */",
+			"SELECT 'order by' AS [order by], [a]]order by] -- order by\nFROM t /* order by */ ORDER BY (SELECT NULL) OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY",
+		},
+		{
+			"SELECT ROW_NUMBER() OVER (ORDER BY id) AS n FROM t ORDER BY n;",
+			"SELECT ROW_NUMBER() OVER (ORDER BY id) AS n FROM t ORDER BY n OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY",
+		},
+	}
+	for _, tt := range tests {
+		if got := d.Paginate(tt.q, 10, 20, nil); got != tt.want {
+			t.Errorf("Paginate(%q) = %q", tt.q, got)
+		}
+	}
+}
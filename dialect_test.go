@@ -0,0 +1,216 @@
+package zinc
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRebindQuestion(t *testing.T) {
+	cases := []struct {
+		bindType int
+		q, want  string
+	}{
+		{bindDollar, "SELECT * FROM t WHERE a = ? AND b = ?", "SELECT * FROM t WHERE a = $1 AND b = $2"},
+		{bindAt, "SELECT ?, ?", "SELECT @p1, @p2"},
+		{bindNamed, "SELECT ?, ?", "SELECT :1, :2"},
+		{bindQuestion, "SELECT ?, ??", "SELECT ?, ??"},
+		{bindDollar, "SELECT '?', \"?\", ?", "SELECT '?', \"?\", $1"},
+		{bindDollar, "SELECT doc ?? 'k', doc ??| ?, doc ??& ?", "SELECT doc ? 'k', doc ?| $1, doc ?& $2"},
+		{bindDollar, "SELECT ? -- why?\n, ?", "SELECT $1 -- why?\n, $2"},
+		{bindDollar, "SELECT ? /* a ? b */, ?", "SELECT $1 /* a ? b */, $2"},
+		{bindDollar, "SELECT $$a ? b$$, $fn$ ? $$ ? $fn$, ?", "SELECT $$a ? b$$, $fn$ ? $$ ? $fn$, $1"},
+		{bindDollar, "SELECT $1, ?", "SELECT $1, $1"},
+		{bindDollar, "SELECT 'unterminated ?", "SELECT 'unterminated ?"},
+	}
+	for _, c := range cases {
+		if got := rebindQuestion(c.q, c.bindType); got != c.want {
+			t.Errorf("rebindQuestion(%q, %d) = %q, want %q", c.q, c.bindType, got, c.want)
+		}
+	}
+}
+
+func TestBindPostgresEscapedQuestion(t *testing.T) {
+	db := newBindTestDB(t, "postgres")
+	tests := []struct {
+		q     string
+		args  []any
+		bound string
+	}{
+		{"SELECT * FROM t WHERE doc ?? 'k' AND id IN (?) -- (?)", []any{[]int{1, 2}}, "SELECT * FROM t WHERE doc ? 'k' AND id IN ($1, $2) -- (?)"},
+		{"SELECT * FROM t WHERE doc ??| array['k'] AND x = :x", []any{map[string]any{"x": 1}}, "SELECT * FROM t WHERE doc ?| array['k'] AND x = $1"},
+		{"SELECT doc ?? 'k' FROM t", nil, "SELECT doc ? 'k' FROM t"},
+	}
+	for _, tt := range tests {
+		uArgs, _ := United(tt.args...)
+		bound, _, err := db.Bind(tt.q, uArgs)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.q, err)
+		}
+		if bound != tt.bound {
+			t.Errorf("Bind(%q) = %q", tt.q, bound)
+		}
+	}
+}
+
+func TestQuoteWith(t *testing.T) {
+	tests := []struct {
+		s         string
+		quoteType int
+		want      string
+	}{
+		{"t", quoteDouble, `"t"`},
+		{" s.t.c ", quoteDouble, `"s"."t"."c"`},
+		{`a"b`, quoteDouble, `"a""b"`},
+		{`"A.b"."C"`, quoteDouble, `"A.b"."C"`},
+		{`"a""b".c`, quoteDouble, `"a""b"."c"`},
+		{"s . t", quoteBack, "`s`.`t`"},
+		{"a`b", quoteBack, "`a``b`"},
+		{"[a]]b].c", quoteBracket, "[a]]b].[c]"},
+		{"a]b", quoteBracket, "[a]]b]"},
+		{"", quoteDouble, ""},
+		{"a..b", quoteDouble, ""},
+		{".a", quoteDouble, ""},
+		{"a.", quoteDouble, ""},
+		{`"a`, quoteDouble, ""},
+		{`"a"b`, quoteDouble, ""},
+		{"a\x00b", quoteDouble, ""},
+	}
+	for _, tt := range tests {
+		if got := quote(tt.s, tt.quoteType); got != tt.want {
+			t.Errorf("quote(%q, %d) = %q, want %q", tt.s, tt.quoteType, got, tt.want)
+		}
+	}
+	// only parts that the caller didn't quote are folded
+	if got := quoteWith(`S."T".c`, quoteDouble, strings.ToLower); got != `"s"."T"."c"` {
+		t.Errorf("quoteWith = %q", got)
+	}
+}
+
+func TestSplitIdentifier(t *testing.T) {
+	parts, ok := splitIdentifier(`a."b.c"."d""e"`, '"', '"')
+	want := []identifierPart{{name: "a"}, {name: "b.c", quoted: true}, {name: `d"e`, quoted: true}}
+	if !ok || !reflect.DeepEqual(parts, want) {
+		t.Fatalf("splitIdentifier = %v %v", parts, ok)
+	}
+	for _, s := range []string{"", "a.", "a. ", `"a`, `"a"x`} {
+		if _, ok := splitIdentifier(s, '"', '"'); ok {
+			t.Errorf("splitIdentifier(%q) is ok", s)
+		}
+	}
+}
+
+func TestConvertNumber(t *testing.T) {
+	tests := []struct {
+		v    any
+		want any
+	}{
+		{int64(-5), int8(-5)},
+		{int64(255), uint8(255)},
+		{int64(3), float32(3)},
+		{uint64(math.MaxInt64), int64(math.MaxInt64)},
+		{uint64(7), uint16(7)},
+		{float64(42), int32(42)},
+		{float64(-1), int(-1)},
+		{float64(1e10), uint64(1e10)},
+		{float64(1.5), float32(1.5)},
+	}
+	for _, tt := range tests {
+		got, err := convertNumber(reflect.ValueOf(tt.v), reflect.TypeOf(tt.want))
+		if err != nil {
+			t.Errorf("convertNumber(%T %v, %T): %v", tt.v, tt.v, tt.want, err)
+			continue
+		}
+		if got.Interface() != tt.want {
+			t.Errorf("convertNumber(%T %v) = %v, want %v", tt.v, tt.v, got.Interface(), tt.want)
+		}
+	}
+
+	failures := []struct {
+		v      any
+		toType reflect.Type
+	}{
+		{int64(128), reflect.TypeOf(int8(0))},
+		{int64(-1), reflect.TypeOf(uint(0))},
+		{uint64(math.MaxInt64 + 1), reflect.TypeOf(int64(0))},
+		{uint64(256), reflect.TypeOf(uint8(0))},
+		{float64(1.5), reflect.TypeOf(int(0))},
+		{float64(-1), reflect.TypeOf(uint(0))},
+		{float64(math.MaxInt64), reflect.TypeOf(int64(0))},
+		{float64(1e40), reflect.TypeOf(float32(0))},
+		{float64(1), reflect.TypeOf("")},
+		{"1", reflect.TypeOf(int(0))},
+	}
+	for _, tt := range failures {
+		if got, err := convertNumber(reflect.ValueOf(tt.v), tt.toType); err == nil {
+			t.Errorf("convertNumber(%T %v, %s) = %v, want error", tt.v, tt.v, tt.toType, got.Interface())
+		}
+	}
+}
+
+func TestParseNumberText(t *testing.T) {
+	tests := []struct {
+		s    string
+		want any
+	}{
+		{" 42 ", int(42)},
+		{"-9223372036854775808", int64(math.MinInt64)},
+		{"18446744073709551615", uint64(math.MaxUint64)},
+		{"12.000", int16(12)},
+		{"1e3", uint32(1000)},
+		{"3.25", float64(3.25)},
+		{"-0.5", float32(-0.5)},
+	}
+	for _, tt := range tests {
+		got, err := parseNumberText(tt.s, reflect.TypeOf(tt.want))
+		if err != nil {
+			t.Errorf("parseNumberText(%q, %T): %v", tt.s, tt.want, err)
+			continue
+		}
+		if got.Interface() != tt.want {
+			t.Errorf("parseNumberText(%q) = %v, want %v", tt.s, got.Interface(), tt.want)
+		}
+	}
+
+	failures := []struct {
+		s      string
+		toType reflect.Type
+	}{
+		{"", reflect.TypeOf(int(0))},
+		{"abc", reflect.TypeOf(float64(0))},
+		{"12.5", reflect.TypeOf(int(0))},
+		{"300", reflect.TypeOf(uint8(0))},
+		{"-1", reflect.TypeOf(uint(0))},
+		{"18446744073709551616", reflect.TypeOf(uint64(0))},
+	}
+	for _, tt := range failures {
+		if got, err := parseNumberText(tt.s, tt.toType); err == nil {
+			t.Errorf("parseNumberText(%q, %s) = %v, want error", tt.s, tt.toType, got.Interface())
+		}
+	}
+}
+
+func TestBindMysqlBackslashEscapes(t *testing.T) {
+	db := newBindTestDB(t, "mysql")
+	// MySQL doesn't rebind, every '?' is a placeholder and '??' is not an escape
+	tests := []struct {
+		q       string
+		args    []any
+		bound   string
+		wantArg []any
+	}{
+		{`SELECT * FROM t WHERE name = 'O\'Brien' AND id IN (?)`, []any{[]int{1, 2}}, `SELECT * FROM t WHERE name = 'O\'Brien' AND id IN (?, ?)`, []any{1, 2}},
+		{"SELECT * FROM t WHERE a = ?? AND id IN (?)", []any{1, 2, []int{3, 4}}, "SELECT * FROM t WHERE a = ?? AND id IN (?, ?)", []any{1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		uArgs, _ := United(tt.args...)
+		bound, boundArgs, err := db.Bind(tt.q, uArgs)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.q, err)
+		}
+		if bound != tt.bound || !reflect.DeepEqual(boundArgs, tt.wantArg) {
+			t.Errorf("Bind(%q) = %q %#v", tt.q, bound, boundArgs)
+		}
+	}
+}
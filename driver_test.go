@@ -0,0 +1,247 @@
+package zinc
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// testColumn is a result column of the test driver
+type testColumn struct {
+	name     string
+	typeName string
+	scanType reflect.Type
+}
+
+// testDriver is a database/sql driver that records what it is asked to do and answers
+// queries with canned rows, each DSN has its own state
+type testDriver struct {
+	mu     sync.Mutex
+	log    []string
+	cols   []testColumn
+	rows   [][]driver.Value
+	args   [][]driver.NamedValue
+	closed int
+}
+
+var (
+	testDrivers   = map[string]*testDriver{}
+	testDriversMu sync.Mutex
+	testDSNSeq    int64
+)
+
+func init() {
+	sql.Register("zinctest", testConnector{})
+}
+
+// openTestDB opens a *sql.DB on a fresh test driver
+func openTestDB(t *testing.T) (*sql.DB, *testDriver) {
+	t.Helper()
+	dsn, d := newTestDSN()
+	sqlDB, err := sql.Open("zinctest", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	return sqlDB, d
+}
+
+// newTestDSN registers a fresh test driver state under a new DSN
+func newTestDSN() (string, *testDriver) {
+	dsn := fmt.Sprintf("test-%d", atomic.AddInt64(&testDSNSeq, 1))
+	d := &testDriver{}
+	testDriversMu.Lock()
+	testDrivers[dsn] = d
+	testDriversMu.Unlock()
+	return dsn, d
+}
+
+func (d *testDriver) record(s string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.log = append(d.log, s)
+}
+
+func (d *testDriver) setRows(cols []testColumn, rows ...[]driver.Value) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.cols, d.rows = cols, rows
+}
+
+func (d *testDriver) logged() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return cloneSlice(d.log)
+}
+
+func (d *testDriver) lastArgs() []driver.NamedValue {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.args) == 0 {
+		return nil
+	}
+	return d.args[len(d.args)-1]
+}
+
+func (d *testDriver) newRows(args []driver.NamedValue) *testRows {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.args = append(d.args, args)
+	return &testRows{d: d, cols: d.cols, rows: d.rows}
+}
+
+type testConnector struct{}
+
+func (testConnector) Open(dsn string) (driver.Conn, error) {
+	testDriversMu.Lock()
+	d := testDrivers[dsn]
+	testDriversMu.Unlock()
+	if d == nil {
+		return nil, fmt.Errorf("unknown test dsn %s", dsn)
+	}
+	d.record("OPEN")
+	return &testConn{d: d}, nil
+}
+
+type testConn struct {
+	d *testDriver
+}
+
+func (c *testConn) Prepare(q string) (driver.Stmt, error) {
+	c.d.record("PREPARE " + q)
+	return &testStmt{d: c.d, q: q}, nil
+}
+
+func (c *testConn) Close() error {
+	return nil
+}
+
+func (c *testConn) Begin() (driver.Tx, error) {
+	c.d.record("BEGIN")
+	return testTx{d: c.d}, nil
+}
+
+func (c *testConn) ExecContext(_ context.Context, q string, args []driver.NamedValue) (driver.Result, error) {
+	c.d.record("EXEC " + q)
+	c.d.newRows(args)
+	return driver.RowsAffected(1), nil
+}
+
+func (c *testConn) QueryContext(_ context.Context, q string, args []driver.NamedValue) (driver.Rows, error) {
+	c.d.record("QUERY " + q)
+	return c.d.newRows(args), nil
+}
+
+// CheckNamedValue accepts every value so that tests see exactly what was bound
+func (c *testConn) CheckNamedValue(*driver.NamedValue) error {
+	return nil
+}
+
+type testTx struct {
+	d *testDriver
+}
+
+func (tx testTx) Commit() error {
+	tx.d.record("COMMIT")
+	return nil
+}
+
+func (tx testTx) Rollback() error {
+	tx.d.record("ROLLBACK")
+	return nil
+}
+
+type testStmt struct {
+	d *testDriver
+	q string
+}
+
+func (s *testStmt) Close() error {
+	s.d.record("CLOSE " + s.q)
+	s.d.mu.Lock()
+	s.d.closed++
+	s.d.mu.Unlock()
+	return nil
+}
+
+func (s *testStmt) NumInput() int {
+	return -1
+}
+
+func (s *testStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.record("STMT EXEC " + s.q)
+	s.d.newRows(namedValues(args))
+	return driver.RowsAffected(1), nil
+}
+
+func (s *testStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.record("STMT QUERY " + s.q)
+	return s.d.newRows(namedValues(args)), nil
+}
+
+// ExecContext and QueryContext keep the names of native named args
+func (s *testStmt) ExecContext(_ context.Context, args []driver.NamedValue) (driver.Result, error) {
+	s.d.record("STMT EXEC " + s.q)
+	s.d.newRows(args)
+	return driver.RowsAffected(1), nil
+}
+
+func (s *testStmt) QueryContext(_ context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	s.d.record("STMT QUERY " + s.q)
+	return s.d.newRows(args), nil
+}
+
+func namedValues(args []driver.Value) []driver.NamedValue {
+	r := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		r[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+	return r
+}
+
+type testRows struct {
+	d    *testDriver
+	cols []testColumn
+	rows [][]driver.Value
+	i    int
+}
+
+func (r *testRows) Columns() []string {
+	names := make([]string, len(r.cols))
+	for i, col := range r.cols {
+		names[i] = col.name
+	}
+	return names
+}
+
+func (r *testRows) Close() error {
+	r.d.record("ROWS CLOSE")
+	return nil
+}
+
+func (r *testRows) Next(dest []driver.Value) error {
+	if r.i >= len(r.rows) {
+		return io.EOF
+	}
+	r.d.record("NEXT")
+	copy(dest, r.rows[r.i])
+	r.i++
+	return nil
+}
+
+func (r *testRows) ColumnTypeDatabaseTypeName(i int) string {
+	return r.cols[i].typeName
+}
+
+func (r *testRows) ColumnTypeScanType(i int) reflect.Type {
+	if r.cols[i].scanType == nil {
+		return typAny
+	}
+	return r.cols[i].scanType
+}
@@ -6,9 +6,14 @@ import (
 )
 
 var (
-	ErrUnsupportedDriver = errors.New("unsupported driver")
-	ErrBind              = errors.New("bind error")
-	ErrInvalidDest       = errors.New("invalid dest")
-	ErrNoRows            = sql.ErrNoRows
-	ErrCoerceDest        = errors.New("coerce dest error")
+	ErrUnsupportedDriver  = errors.New("unsupported driver")
+	ErrBind               = errors.New("bind error")
+	ErrInvalidDest        = errors.New("invalid dest")
+	ErrNoRows             = sql.ErrNoRows
+	ErrCoerceDest         = errors.New("coerce dest error")
+	ErrNullDest           = errors.New("dest is not nullable")
+	ErrInvalidKind        = errors.New("invalid kind")
+	ErrNotInTx            = errors.New("not in transaction")
+	ErrInvalidIdentifier  = errors.New("invalid identifier")
+	ErrUnsupportedCharset = errors.New("unsupported charset")
 )
@@ -2,4 +2,6 @@ module github.com/gaorx/zinc
 
 go 1.18
 
-require github.com/go-sql-driver/mysql v1.7.1 // indirect
+require github.com/go-sql-driver/mysql v1.7.1
+
+require golang.org/x/text v0.14.0
@@ -2,6 +2,8 @@ package zinc
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"fmt"
 	"reflect"
 )
 
@@ -68,18 +70,32 @@ func defaultMapper(src *Src, dest *Dest, opts *Options) error {
 		return err
 	}
 	coerceDest := func(ci *sql.ColumnType, scannedVal reflect.Value, toType reflect.Type) (reflect.Value, error) {
-		return opts.Dialect.CoerceDest(ci, scannedVal, toType, opts)
+		return coerceColumn(ci, scannedVal, toType, opts)
 	}
 	switch dest.Kind {
 	case StructPtr:
-		panic("not implemented")
+		dv := reflect.ValueOf(dest.Target).Elem()
+		fields := structFieldsByColumn(dest.Type, opts)
+		for i := range src.Columns {
+			f, ok := fields[src.Columns[i]]
+			if !ok {
+				continue
+			}
+			ci, dv1 := src.ColumnTypes[i], reflect.ValueOf(destSlice[i]).Elem()
+			targetVal, err := coerceDest(ci, dv1, f.Type)
+			if err != nil {
+				return err
+			}
+			structFieldValue(dv, f).Set(targetVal)
+		}
+		return nil
 	case Map:
 		dv := reflect.ValueOf(dest.Target)
 		for i := range src.Columns {
 			ci, cn, dv1 := src.ColumnTypes[i], src.Columns[i], reflect.ValueOf(destSlice[i]).Elem()
 			targetVal, err := coerceDest(ci, dv1, dest.Type.Elem())
 			if err != nil {
-				return ErrCoerceDest
+				return err
 			}
 			dv.SetMapIndex(reflect.ValueOf(cn), targetVal)
 		}
@@ -88,9 +104,9 @@ func defaultMapper(src *Src, dest *Dest, opts *Options) error {
 		dv := reflect.ValueOf(dest.Target)
 		dv0 := reflect.ValueOf(destSlice[0]).Elem()
 		ci0 := src.ColumnTypes[0]
-		targetVal, err := coerceDest(ci0, dv0, dest.Type.Elem())
+		targetVal, err := coerceDest(ci0, dv0, dest.Type)
 		if err != nil {
-			return ErrCoerceDest
+			return err
 		}
 		dv.Elem().Set(targetVal)
 		return nil
@@ -102,7 +118,7 @@ func defaultMapper(src *Src, dest *Dest, opts *Options) error {
 			dv1 := reflect.ValueOf(destSlice[i]).Elem()
 			targetVal, err := coerceDest(ci1, dv1, dest.Type.Elem())
 			if err != nil {
-				return ErrCoerceDest
+				return err
 			}
 			rv = reflect.Append(rv, targetVal)
 		}
@@ -112,3 +128,159 @@ func defaultMapper(src *Src, dest *Dest, opts *Options) error {
 		panic("unreachable")
 	}
 }
+
+// coerceColumn coerces a scanned column value to toType, NULLs and pointers to
+// primitives are handled here so that dialects only see non-NULL values
+func coerceColumn(ci *sql.ColumnType, scannedVal reflect.Value, toType reflect.Type, opts *Options) (reflect.Value, error) {
+	// values scanned through a pointer by newScanDest
+	if scannedVal.Kind() == reflect.Ptr && !scannedVal.IsNil() {
+		scannedVal = scannedVal.Elem()
+	}
+	var r reflect.Value
+	var err error
+	if isNullValue(scannedVal) {
+		r, err = coerceNull(toType, opts)
+	} else if isScanner(toType) && !hasFromDBConverter(toType, opts) {
+		// the scanner gets the driver value, not the dialect's idea of it
+		r, err = scanColumn(scannedVal, toType)
+	} else if toType.Kind() == reflect.Ptr && isScalar(toType.Elem(), opts) {
+		var elemVal reflect.Value
+		elemVal, err = coerceColumnValue(ci, scannedVal, toType.Elem(), opts)
+		if err == nil {
+			r = reflect.New(toType.Elem())
+			r.Elem().Set(elemVal)
+		}
+	} else {
+		r, err = coerceColumnValue(ci, scannedVal, toType, opts)
+	}
+	if err != nil {
+		return reflect.Value{}, newCoerceError(ci, scannedVal, toType, err)
+	}
+	return r, nil
+}
+
+// scanColumn creates a toType, or the value it points to, and scans the driver value into it
+func scanColumn(scannedVal reflect.Value, toType reflect.Type) (reflect.Value, error) {
+	src, err := driverValueOf(scannedVal)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if toType.Kind() == reflect.Ptr && toType.Implements(typScanner) {
+		r := reflect.New(toType.Elem())
+		if err := r.Interface().(sql.Scanner).Scan(src); err != nil {
+			return reflect.Value{}, err
+		}
+		return r, nil
+	}
+	return scanInto(reflect.ValueOf(src), toType)
+}
+
+// driverValueOf returns the driver value of a scanned column, unwrapping the Null* types
+// a dialect may scan into and copying buffers the driver may reuse
+func driverValueOf(scannedVal reflect.Value) (any, error) {
+	if isNullValue(scannedVal) {
+		// an untyped nil, a nil []byte in an any is not NULL to a Scanner
+		return nil, nil
+	}
+	switch a := scannedVal.Interface().(type) {
+	case sql.RawBytes:
+		return cloneSlice([]byte(a)), nil
+	case []byte:
+		return cloneSlice(a), nil
+	case driver.Valuer:
+		return a.Value()
+	default:
+		return a, nil
+	}
+}
+
+// coerceColumnValue coerces a non-NULL value, types with a registered converter get the value normalized by the dialect
+func coerceColumnValue(ci *sql.ColumnType, scannedVal reflect.Value, toType reflect.Type, opts *Options) (reflect.Value, error) {
+	if hasFromDBConverter(toType, opts) {
+		normalized, err := opts.Dialect.CoerceDest(ci, scannedVal, typAny, opts)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return convertFromDB(normalized, toType, opts)
+	}
+	return opts.Dialect.CoerceDest(ci, scannedVal, toType, opts)
+}
+
+// coerceError is a failed column coercion, it matches ErrCoerceDest and unwraps to the cause
+type coerceError struct {
+	column   string
+	fromType string
+	toType   reflect.Type
+	err      error
+}
+
+func newCoerceError(ci *sql.ColumnType, scannedVal reflect.Value, toType reflect.Type, err error) error {
+	e := &coerceError{toType: toType, err: err}
+	if ci != nil {
+		e.column = ci.Name()
+	}
+	if isNullValue(scannedVal) {
+		e.fromType = "NULL"
+	} else if scannedVal.Kind() == reflect.Interface {
+		e.fromType = scannedVal.Elem().Type().String()
+	} else {
+		e.fromType = scannedVal.Type().String()
+	}
+	return e
+}
+
+func (e *coerceError) Error() string {
+	return fmt.Sprintf("%s: column %s: can't coerce %s to %s: %s", ErrCoerceDest, e.column, e.fromType, e.toType, e.err)
+}
+
+func (e *coerceError) Is(target error) bool {
+	return target == ErrCoerceDest
+}
+
+func (e *coerceError) Unwrap() error {
+	return e.err
+}
+
+// structFieldsByColumn maps column names to fields, by zcol/zcols tags or the resolved column name
+func structFieldsByColumn(t reflect.Type, opts *Options) map[string]*StructField {
+	s, ok := ParseStruct(t)
+	if !ok {
+		return nil
+	}
+	resolver := opts.NameResolver
+	if resolver == nil {
+		resolver = DefaultNameResolver
+	}
+	fields := make(map[string]*StructField, len(s.Fields))
+	for _, f := range s.Fields {
+		var cols []string
+		if f.TagCol != "" {
+			cols = append(cols, f.TagCol)
+		}
+		cols = append(cols, f.TagCols...)
+		if len(cols) == 0 {
+			fieldName := f.Paths[len(f.Paths)-1].Name
+			cols = append(cols, resolver.ResolveColumnName(s.Name, fieldName))
+		}
+		for _, col := range cols {
+			if _, ok := fields[col]; !ok {
+				fields[col] = f
+			}
+		}
+	}
+	return fields
+}
+
+// structFieldValue returns the settable field of v, allocating nil embedded struct pointers on the way
+func structFieldValue(v reflect.Value, f *StructField) reflect.Value {
+	for _, path := range f.Paths {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.FieldByIndex(path.Index)
+	}
+	return v
+}
@@ -0,0 +1,187 @@
+package zinc
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type nullTestRow struct {
+	Name *string `zcol:"name"`
+	Age  int64   `zcol:"age"`
+	Nick *string `zcol:"nick"`
+}
+
+// mysqlNullColumns mimics the scan types go-sql-driver/mysql reports for nullable columns
+var mysqlNullColumns = []testColumn{
+	{name: "name", typeName: "VARCHAR", scanType: typRawBytes},
+	{name: "age", typeName: "BIGINT", scanType: typInt64},
+	{name: "nick", typeName: "VARCHAR", scanType: reflectTypeOf[sql.NullString]()},
+}
+
+func TestMapNullIntoStruct(t *testing.T) {
+	sqlDB, d := openTestDB(t)
+	db, err := New("mysql", sqlDB, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.setRows(mysqlNullColumns,
+		[]driver.Value{nil, nil, nil},
+		[]driver.Value{[]byte("bob"), int64(3), "b"},
+	)
+	var rows []nullTestRow
+	if err := db.RawQueryAll(&rows, "SELECT"); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows", len(rows))
+	}
+	if r := rows[0]; r.Name != nil || r.Age != 0 || r.Nick != nil {
+		t.Fatalf("NULL row = %+v", r)
+	}
+	if r := rows[1]; r.Name == nil || *r.Name != "bob" || r.Age != 3 || r.Nick == nil || *r.Nick != "b" {
+		t.Fatalf("row = %+v", r)
+	}
+}
+
+func TestMapNullIntoMap(t *testing.T) {
+	sqlDB, d := openTestDB(t)
+	db, err := New("mysql", sqlDB, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.setRows(mysqlNullColumns, []driver.Value{nil, nil, nil})
+	var m map[string]any
+	if err := db.RawQueryOne(&m, "SELECT"); err != nil {
+		t.Fatal(err)
+	}
+	for _, col := range []string{"name", "age", "nick"} {
+		if v, ok := m[col]; !ok || v != nil {
+			t.Fatalf("m[%s] = %v, %v", col, v, ok)
+		}
+	}
+}
+
+func TestMapNullStrict(t *testing.T) {
+	sqlDB, d := openTestDB(t)
+	db, err := New("mysql", sqlDB, &Options{StrictNull: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.setRows(mysqlNullColumns, []driver.Value{nil, nil, nil})
+	var rows []nullTestRow
+	err = db.RawQueryAll(&rows, "SELECT")
+	if !errors.Is(err, ErrNullDest) || !errors.Is(err, ErrCoerceDest) {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func reflectTypeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// testScanner records what it was scanned from
+type testScanner struct {
+	src   any
+	valid bool
+}
+
+func (s *testScanner) Scan(src any) error {
+	s.src, s.valid = src, src != nil
+	return nil
+}
+
+type scannerTestRow struct {
+	Name  sql.NullString `zcol:"name"`
+	Tags  testScanner    `zcol:"tags"`
+	PTags *testScanner   `zcol:"ptags"`
+}
+
+func TestMapScanner(t *testing.T) {
+	sqlDB, d := openTestDB(t)
+	db, err := New("mysql", sqlDB, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.setRows([]testColumn{
+		{name: "name", typeName: "VARCHAR", scanType: typRawBytes},
+		{name: "tags", typeName: "JSON", scanType: typRawBytes},
+		{name: "ptags", typeName: "JSON", scanType: typRawBytes},
+	},
+		[]driver.Value{nil, nil, nil},
+		[]driver.Value{[]byte("bob"), []byte(`{"a":1}`), []byte(`[1]`)},
+	)
+	var rows []scannerTestRow
+	if err := db.RawQueryAll(&rows, "SELECT"); err != nil {
+		t.Fatal(err)
+	}
+	if r := rows[0]; r.Name.Valid || r.Tags.valid || r.Tags.src != nil || r.PTags != nil {
+		t.Fatalf("NULL row = %+v", r)
+	}
+	r := rows[1]
+	if !r.Name.Valid || r.Name.String != "bob" {
+		t.Fatalf("Name = %+v", r.Name)
+	}
+	// the raw driver value, not the dialect's json.RawMessage
+	if b, ok := r.Tags.src.([]byte); !ok || string(b) != `{"a":1}` {
+		t.Fatalf("Tags scanned from %T %v", r.Tags.src, r.Tags.src)
+	}
+	if r.PTags == nil || !r.PTags.valid {
+		t.Fatalf("PTags = %+v", r.PTags)
+	}
+
+	var names []sql.NullString
+	if err := db.RawQueryAll(&names, "SELECT"); err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 2 || names[0].Valid || names[1].String != "bob" {
+		t.Fatalf("names = %+v", names)
+	}
+}
+
+func TestDriverValueOfNil(t *testing.T) {
+	for _, v := range []any{sql.RawBytes(nil), []byte(nil), sql.NullString{}} {
+		src, err := driverValueOf(reflect.ValueOf(v))
+		if err != nil || src != nil {
+			t.Fatalf("driverValueOf(%#v) = %#v, %v", v, src, err)
+		}
+	}
+}
+
+type MapTestBase struct {
+	ID int64 `zcol:"id"`
+}
+
+type structTestRow struct {
+	*MapTestBase
+	UserName string
+	Title    string `zcols:"title,headline"`
+}
+
+func TestMapStructRows(t *testing.T) {
+	sqlDB, d := openTestDB(t)
+	db, err := New("sqlite3", sqlDB, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.setRows([]testColumn{{name: "id"}, {name: "UserName"}, {name: "headline"}, {name: "extra"}},
+		[]driver.Value{int64(1), "a", "x", "?"},
+		[]driver.Value{int64(2), "b", "y", "?"},
+	)
+	var rows []structTestRow
+	if err := db.RawQueryAll(&rows, "SELECT"); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows", len(rows))
+	}
+	// each row gets its own embedded pointer
+	if rows[0].MapTestBase == rows[1].MapTestBase || rows[0].ID != 1 || rows[1].ID != 2 {
+		t.Fatalf("ids = %d %d", rows[0].ID, rows[1].ID)
+	}
+	if rows[1].UserName != "b" || rows[1].Title != "y" {
+		t.Fatalf("row = %+v", rows[1])
+	}
+}
@@ -1,5 +1,9 @@
 package zinc
 
+import (
+	"time"
+)
+
 type Options struct {
 	// dialect
 	Dialect      Dialect
@@ -7,6 +11,32 @@ type Options struct {
 	NameResolver NameResolver
 	TextCharset  string
 
+	// connection
+	ConnInit ConnInitializer
+
+	// time
+	TimeLocation       *time.Location
+	TimeRejectZeroDate bool
+
+	// null
+	StrictNull bool
+
+	// converters
+	Converters *Converters
+
+	// statement cache, statements are prepared and cached by queries outside transactions,
+	// transactions reuse cached statements but run misses unprepared
+	StmtCacheSize int
+
+	// replicas
+	ReplicaPolicy        int
+	ForcePrimary         bool
+	ReadYourWritesWindow time.Duration // needs ContextWithReadYourWrites
+
+	// transaction retry
+	TxMaxAttempts  int
+	TxRetryBackoff time.Duration
+
 	// log
 	Logger           Logger
 	LogFormatter     LogFormatter
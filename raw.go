@@ -1,6 +1,7 @@
 package zinc
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"reflect"
@@ -8,49 +9,27 @@ import (
 
 func (db *DB) RawExec(dest any, q string, args ...any) error {
 	uArgs, optsModifier := United(args...)
-	bound, boundArgs, err := db.Bind(q, uArgs)
+	opts := copyOptions(db.options, optsModifier)
+	bound, boundArgs, err := db.bindWith(q, uArgs, opts)
 	if err != nil {
 		return fmt.Errorf("%w: %s", ErrBind, err.Error())
 	}
-	opts := copyOptions(db.options, optsModifier)
-	var sqlRes sql.Result
-	switch db.kind {
-	case kindDB:
-		sqlRes, err = logDo(
-			db.getCtx(),
-			q, uArgs,
-			bound, boundArgs,
-			opts,
-			func() (sql.Result, error) {
-				return db.db.ExecContext(db.getCtx(), bound, boundArgs...)
-			},
-		)
-	case kindTx:
-		sqlRes, err = logDo(
-			db.getCtx(),
-			q, uArgs,
-			bound, boundArgs,
-			opts,
-			func() (sql.Result, error) {
-				return db.tx.ExecContext(db.getCtx(), bound, boundArgs...)
-			},
-		)
-	case kindSt:
-		sqlRes, err = logDo(
-			db.getCtx(),
-			q, uArgs,
-			bound, boundArgs,
-			opts,
-			func() (sql.Result, error) {
-				return db.st.ExecContext(db.getCtx(), boundArgs...)
-			},
-		)
-	default:
-		panic("unreachable")
-	}
+	sqlRes, err := logDo(
+		db.getCtx(),
+		q, uArgs,
+		bound, boundArgs,
+		opts,
+		func() (sql.Result, error) {
+			return db.execContext(db.getCtx(), bound, boundArgs, opts)
+		},
+	)
 	if err != nil {
 		return err
 	}
+	db.txState.noteStatement()
+	if db.replicas != nil {
+		markWritten(db.getCtx())
+	}
 	switch d := dest.(type) {
 	case nil:
 		// do nothing
@@ -83,49 +62,29 @@ func (db *DB) RawExec(dest any, q string, args ...any) error {
 
 func (db *DB) RawQueryOne(dest any, q string, args ...any) error {
 	uArgs, optsModifier := United(args...)
-	bound, boundArgs, err := db.Bind(q, uArgs)
+	opts := copyOptions(db.options, optsModifier)
+	bound, boundArgs, err := db.bindWith(q, uArgs, opts)
 	if err != nil {
 		return fmt.Errorf("%w: %s", ErrBind, err.Error())
 	}
-	opts := copyOptions(db.options, optsModifier)
-	var rows *sql.Rows
-	switch db.kind {
-	case kindDB:
-		rows, err = logDo(
-			db.getCtx(),
-			q, uArgs,
-			bound, boundArgs,
-			opts,
-			func() (*sql.Rows, error) {
-				return db.db.QueryContext(db.getCtx(), bound, boundArgs...)
-			},
-		)
-	case kindTx:
-		rows, err = logDo(
-			db.getCtx(),
-			q, uArgs,
-			bound, boundArgs,
-			opts,
-			func() (*sql.Rows, error) {
-				return db.tx.QueryContext(db.getCtx(), bound, boundArgs...)
-			},
-		)
-	case kindSt:
-		rows, err = logDo(
-			db.getCtx(),
-			q, uArgs,
-			bound, boundArgs,
-			opts,
-			func() (*sql.Rows, error) {
-				return db.st.QueryContext(db.getCtx(), boundArgs...)
-			},
-		)
-	default:
-		panic("unreachable")
-	}
+	var release func()
+	rows, err := logDo(
+		db.getCtx(),
+		q, uArgs,
+		bound, boundArgs,
+		opts,
+		func() (*sql.Rows, error) {
+			var rows *sql.Rows
+			var err error
+			rows, release, err = db.queryContext(db.getCtx(), bound, boundArgs, opts)
+			return rows, err
+		},
+	)
 	if err != nil {
 		return err
 	}
+	db.txState.noteStatement()
+	defer release()
 	defer func(rows *sql.Rows) {
 		_ = rows.Close()
 	}(rows)
@@ -173,49 +132,29 @@ func (db *DB) RawQueryOne(dest any, q string, args ...any) error {
 
 func (db *DB) RawQueryAll(dest any, q string, args ...any) error {
 	uArgs, optsModifier := United(args...)
-	bound, boundArgs, err := db.Bind(q, uArgs)
+	opts := copyOptions(db.options, optsModifier)
+	bound, boundArgs, err := db.bindWith(q, uArgs, opts)
 	if err != nil {
 		return fmt.Errorf("%w: %s", ErrBind, err.Error())
 	}
-	opts := copyOptions(db.options, optsModifier)
-	var rows *sql.Rows
-	switch db.kind {
-	case kindDB:
-		rows, err = logDo(
-			db.getCtx(),
-			q, uArgs,
-			bound, boundArgs,
-			opts,
-			func() (*sql.Rows, error) {
-				return db.db.QueryContext(db.getCtx(), bound, boundArgs...)
-			},
-		)
-	case kindTx:
-		rows, err = logDo(
-			db.getCtx(),
-			q, uArgs,
-			bound, boundArgs,
-			opts,
-			func() (*sql.Rows, error) {
-				return db.tx.QueryContext(db.getCtx(), bound, boundArgs...)
-			},
-		)
-	case kindSt:
-		rows, err = logDo(
-			db.getCtx(),
-			q, uArgs,
-			bound, boundArgs,
-			opts,
-			func() (*sql.Rows, error) {
-				return db.st.QueryContext(db.getCtx(), boundArgs...)
-			},
-		)
-	default:
-		panic("unreachable")
-	}
+	var release func()
+	rows, err := logDo(
+		db.getCtx(),
+		q, uArgs,
+		bound, boundArgs,
+		opts,
+		func() (*sql.Rows, error) {
+			var rows *sql.Rows
+			var err error
+			rows, release, err = db.queryContext(db.getCtx(), bound, boundArgs, opts)
+			return rows, err
+		},
+	)
 	if err != nil {
 		return err
 	}
+	db.txState.noteStatement()
+	defer release()
 	defer func(rows *sql.Rows) {
 		_ = rows.Close()
 	}(rows)
@@ -254,41 +193,49 @@ func (db *DB) RawQueryAll(dest any, q string, args ...any) error {
 		if err := src.fetchColumns(false); err != nil {
 			return err
 		}
-		mapRow := makeMapRowFunc(et, mapper, opts)
-		if ok := isStruct(et); ok {
-			elemDest := reflect.New(et).Interface()
+		// primitives, pointers to primitives and structs are scanned through a pointer, any rows
+		// are scanned into a map
+		rowTyp := et
+		if isScalar(et, opts) || isNullableScalar(et, opts) || isStruct(et) {
+			rowTyp = reflect.PtrTo(et)
+		} else if isAny(et) {
+			rowTyp = reflect.TypeOf(map[string]any{})
+		}
+		mapRow := makeMapRowFunc(rowTyp, mapper, opts)
+		if ok := isScalar(et, opts) || isNullableScalar(et, opts); ok {
 			for rows.Next() {
+				elemDest := reflect.New(et).Interface()
 				if err := mapRow(&src, elemDest); err != nil {
 					return err
 				}
 				dv.Elem().Set(reflect.Append(dv.Elem(), reflect.ValueOf(elemDest).Elem()))
 			}
 			return rows.Err()
-		} else if et1, ok := isStructPtr(et); ok {
+		} else if ok := isStruct(et); ok {
 			for rows.Next() {
-				elemDest := reflect.New(et1).Interface()
+				elemDest := reflect.New(et).Interface()
 				if err := mapRow(&src, elemDest); err != nil {
 					return err
 				}
-				dv.Elem().Set(reflect.Append(dv.Elem(), reflect.ValueOf(elemDest)))
+				dv.Elem().Set(reflect.Append(dv.Elem(), reflect.ValueOf(elemDest).Elem()))
 			}
 			return rows.Err()
-		} else if ok := isRowMap(et); ok {
+		} else if et1, ok := isStructPtr(et); ok {
 			for rows.Next() {
-				elemDest := reflect.MakeMap(et).Interface()
+				elemDest := reflect.New(et1).Interface()
 				if err := mapRow(&src, elemDest); err != nil {
 					return err
 				}
 				dv.Elem().Set(reflect.Append(dv.Elem(), reflect.ValueOf(elemDest)))
 			}
 			return rows.Err()
-		} else if ok := isPrimitive(et); ok {
+		} else if ok := isRowMap(et); ok {
 			for rows.Next() {
-				elemDest := reflect.New(et).Interface()
+				elemDest := reflect.MakeMap(et).Interface()
 				if err := mapRow(&src, elemDest); err != nil {
 					return err
 				}
-				dv.Elem().Set(reflect.Append(dv.Elem(), reflect.ValueOf(elemDest).Elem()))
+				dv.Elem().Set(reflect.Append(dv.Elem(), reflect.ValueOf(elemDest)))
 			}
 			return rows.Err()
 		} else if ok := isAny(et); ok {
@@ -304,7 +251,56 @@ func (db *DB) RawQueryAll(dest any, q string, args ...any) error {
 			return ErrInvalidDest
 		}
 	}
-	return nil
+}
+
+func (db *DB) execContext(ctx context.Context, bound string, boundArgs []any, opts *Options) (sql.Result, error) {
+	switch db.kind {
+	case kindDB:
+		if c := db.stmtCacheOf(opts); c != nil {
+			return c.exec(ctx, db.db, nil, bound, boundArgs)
+		}
+		return db.db.ExecContext(ctx, bound, boundArgs...)
+	case kindTx:
+		if c := db.stmtCacheOf(opts); c != nil {
+			return c.exec(ctx, db.db, db.tx, bound, boundArgs)
+		}
+		return db.tx.ExecContext(ctx, bound, boundArgs...)
+	case kindSt:
+		return db.st.ExecContext(ctx, boundArgs...)
+	case kindCn:
+		return db.conn.ExecContext(ctx, bound, boundArgs...)
+	default:
+		panic("unreachable")
+	}
+}
+
+// queryContext runs the query, release must be called after the rows are closed
+func (db *DB) queryContext(ctx context.Context, bound string, boundArgs []any, opts *Options) (*sql.Rows, func(), error) {
+	noRelease := func() {}
+	var rows *sql.Rows
+	var err error
+	switch db.kind {
+	case kindDB:
+		if r := db.pickReplica(ctx, opts); r != nil {
+			return r.query(ctx, bound, boundArgs, opts)
+		}
+		if c := db.stmtCacheOf(opts); c != nil {
+			return c.query(ctx, db.db, nil, bound, boundArgs)
+		}
+		rows, err = db.db.QueryContext(ctx, bound, boundArgs...)
+	case kindTx:
+		if c := db.stmtCacheOf(opts); c != nil {
+			return c.query(ctx, db.db, db.tx, bound, boundArgs)
+		}
+		rows, err = db.tx.QueryContext(ctx, bound, boundArgs...)
+	case kindSt:
+		rows, err = db.st.QueryContext(ctx, boundArgs...)
+	case kindCn:
+		rows, err = db.conn.QueryContext(ctx, bound, boundArgs...)
+	default:
+		panic("unreachable")
+	}
+	return rows, noRelease, err
 }
 
 func getMapper(dest any, opts *Options) Mapper {
@@ -327,7 +323,26 @@ func getMapper(dest any, opts *Options) Mapper {
 }
 
 func makeMapRowFunc(dt reflect.Type, mapper Mapper, opts *Options) func(src *Src, dest any) error {
-	if et, ok := isStructPtr(dt); ok {
+	if et, ok := isScalarPtr(dt, opts); ok {
+		return func(src *Src, dest any) error {
+			dv := reflect.ValueOf(dest)
+			if dv.IsNil() {
+				return nil
+			}
+			d := Dest{Target: dest, Kind: PrimitivePtr, Type: et}
+			return mapper(src, &d, opts)
+		}
+	} else if et, ok := isNullableScalarPtr(dt, opts); ok {
+		// NULL sets the pointer to nil
+		return func(src *Src, dest any) error {
+			dv := reflect.ValueOf(dest)
+			if dv.IsNil() {
+				return nil
+			}
+			d := Dest{Target: dest, Kind: PrimitivePtr, Type: et}
+			return mapper(src, &d, opts)
+		}
+	} else if et, ok := isStructPtr(dt); ok {
 		return func(src *Src, dest any) error {
 			dv := reflect.ValueOf(dest)
 			if dv.IsNil() {
@@ -370,15 +385,6 @@ func makeMapRowFunc(dt reflect.Type, mapper Mapper, opts *Options) func(src *Src
 			d := Dest{Target: dv.Elem().Interface(), Kind: Map, Type: et}
 			return mapper(src, &d, opts)
 		}
-	} else if et, ok := isPrimitivePtr(dt); ok {
-		return func(src *Src, dest any) error {
-			dv := reflect.ValueOf(dest)
-			if dv.IsNil() {
-				return nil
-			}
-			d := Dest{Target: dest, Kind: PrimitivePtr, Type: et}
-			return mapper(src, &d, opts)
-		}
 	} else if et, ok := isSlicePtr(dt); ok {
 		return func(src *Src, dest any) error {
 			dv := reflect.ValueOf(dest)
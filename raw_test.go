@@ -0,0 +1,94 @@
+package zinc
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+)
+
+func TestRawQueryOnePrimitive(t *testing.T) {
+	sqlDB, d := openTestDB(t)
+	db, err := New("sqlite3", sqlDB, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.setRows([]testColumn{{name: "n", typeName: "INTEGER"}}, []driver.Value{int64(42)})
+	var n int32
+	if err := db.RawQueryOne(&n, "SELECT"); err != nil || n != 42 {
+		t.Fatalf("n = %d, %v", n, err)
+	}
+	d.setRows([]testColumn{{name: "s", typeName: "TEXT"}}, []driver.Value{"abc"})
+	var s string
+	if err := db.RawQueryOne(&s, "SELECT"); err != nil || s != "abc" {
+		t.Fatalf("s = %q, %v", s, err)
+	}
+}
+
+func TestRawQueryAllElemKinds(t *testing.T) {
+	sqlDB, d := openTestDB(t)
+	db, err := New("sqlite3", sqlDB, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.setRows([]testColumn{{name: "id", typeName: "INTEGER"}}, []driver.Value{int64(1)}, []driver.Value{int64(2)})
+	type row struct {
+		ID int64 `zcol:"id"`
+	}
+
+	var ints []int64
+	if err := db.RawQueryAll(&ints, "SELECT"); err != nil || len(ints) != 2 || ints[1] != 2 {
+		t.Fatalf("ints = %v, %v", ints, err)
+	}
+	var structs []row
+	if err := db.RawQueryAll(&structs, "SELECT"); err != nil || len(structs) != 2 || structs[1].ID != 2 {
+		t.Fatalf("structs = %v, %v", structs, err)
+	}
+	var ptrs []*row
+	if err := db.RawQueryAll(&ptrs, "SELECT"); err != nil || len(ptrs) != 2 || ptrs[0] == ptrs[1] || ptrs[1].ID != 2 {
+		t.Fatalf("ptrs = %v, %v", ptrs, err)
+	}
+	var maps []map[string]any
+	if err := db.RawQueryAll(&maps, "SELECT"); err != nil || len(maps) != 2 || maps[1]["id"] != int64(2) {
+		t.Fatalf("maps = %v, %v", maps, err)
+	}
+	var anys []any
+	if err := db.RawQueryAll(&anys, "SELECT"); err != nil || len(anys) != 2 {
+		t.Fatalf("anys = %v, %v", anys, err)
+	}
+	if m, ok := anys[1].(map[string]any); !ok || m["id"] != int64(2) {
+		t.Fatalf("anys[1] = %#v", anys[1])
+	}
+}
+
+func TestRawQueryNullablePrimitive(t *testing.T) {
+	sqlDB, d := openTestDB(t)
+	db, err := New("sqlite3", sqlDB, &Options{StrictNull: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cols := []testColumn{{name: "n", typeName: "INTEGER"}}
+
+	d.setRows(cols, []driver.Value{nil})
+	p := new(int64)
+	if err := db.RawQueryOne(&p, "SELECT"); err != nil || p != nil {
+		t.Fatalf("p = %v, %v", p, err)
+	}
+	d.setRows(cols, []driver.Value{int64(7)})
+	if err := db.RawQueryOne(&p, "SELECT"); err != nil || p == nil || *p != 7 {
+		t.Fatalf("p = %v, %v", p, err)
+	}
+
+	d.setRows(cols, []driver.Value{int64(1)}, []driver.Value{nil})
+	var ps []*int64
+	if err := db.RawQueryAll(&ps, "SELECT"); err != nil {
+		t.Fatal(err)
+	}
+	if len(ps) != 2 || ps[0] == nil || *ps[0] != 1 || ps[1] != nil {
+		t.Fatalf("ps = %v", ps)
+	}
+	var ts []*time.Time
+	d.setRows([]testColumn{{name: "t", typeName: "DATETIME"}}, []driver.Value{nil})
+	if err := db.RawQueryAll(&ts, "SELECT"); err != nil || len(ts) != 1 || ts[0] != nil {
+		t.Fatalf("ts = %v, %v", ts, err)
+	}
+}
@@ -0,0 +1,173 @@
+package zinc
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	ReplicaRoundRobin    = 0
+	ReplicaLeastInflight = 1
+)
+
+type replicaSet struct {
+	replicas []*replica
+	next     uint64
+}
+
+type replica struct {
+	db       *sql.DB
+	stmts    *stmtCache
+	inflight int64
+}
+
+func NewWithReplicas(driverName string, primary *sql.DB, replicas []*sql.DB, opts *Options) (*DB, error) {
+	db, err := New(driverName, primary, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(replicas) <= 0 {
+		return db, nil
+	}
+	rs := &replicaSet{}
+	for _, replicaDB := range replicas {
+		r := &replica{db: replicaDB}
+		if db.options.StmtCacheSize > 0 {
+			r.stmts = newStmtCache(db.options.StmtCacheSize)
+		}
+		rs.replicas = append(rs.replicas, r)
+	}
+	db.replicas = rs
+	return db, nil
+}
+
+func UsePrimary() OptionsModifier {
+	return func(opts *Options) {
+		opts.ForcePrimary = true
+	}
+}
+
+func (db *DB) Replicas() []*sql.DB {
+	if db.replicas == nil {
+		return nil
+	}
+	r := make([]*sql.DB, 0, len(db.replicas.replicas))
+	for _, replica := range db.replicas.replicas {
+		r = append(r, replica.db)
+	}
+	return r
+}
+
+func (db *DB) pickReplica(ctx context.Context, opts *Options) *replica {
+	if db.replicas == nil || opts.ForcePrimary {
+		return nil
+	}
+	if recentlyWritten(ctx, opts.ReadYourWritesWindow) {
+		return nil
+	}
+	return db.replicas.pick(opts.ReplicaPolicy)
+}
+
+func (rs *replicaSet) pick(policy int) *replica {
+	n := len(rs.replicas)
+	switch policy {
+	case ReplicaLeastInflight:
+		// start from a rotating offset so that ties are spread
+		start := int(atomic.AddUint64(&rs.next, 1) % uint64(n))
+		var picked *replica
+		var least int64
+		for i := 0; i < n; i++ {
+			r := rs.replicas[(start+i)%n]
+			inflight := atomic.LoadInt64(&r.inflight)
+			if picked == nil || inflight < least {
+				picked, least = r, inflight
+			}
+		}
+		return picked
+	default:
+		return rs.replicas[int(atomic.AddUint64(&rs.next, 1)%uint64(n))]
+	}
+}
+
+func (rs *replicaSet) close() error {
+	var firstErr error
+	for _, r := range rs.replicas {
+		if r.stmts != nil {
+			_ = r.stmts.close()
+		}
+		if err := r.db.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// query counts the query as in flight until the returned release is called, after the rows are closed
+func (r *replica) query(ctx context.Context, bound string, boundArgs []any, opts *Options) (*sql.Rows, func(), error) {
+	atomic.AddInt64(&r.inflight, 1)
+	var once sync.Once
+	release := func() {
+		once.Do(func() { atomic.AddInt64(&r.inflight, -1) })
+	}
+	var rows *sql.Rows
+	var err error
+	if r.stmts != nil && opts.StmtCacheSize > 0 {
+		var releaseStmt func()
+		rows, releaseStmt, err = r.stmts.query(ctx, r.db, nil, bound, boundArgs)
+		if err == nil {
+			releaseInflight := release
+			release = func() {
+				releaseStmt()
+				releaseInflight()
+			}
+		}
+	} else {
+		rows, err = r.db.QueryContext(ctx, bound, boundArgs...)
+	}
+	if err != nil {
+		release()
+		return nil, func() {}, err
+	}
+	return rows, release, nil
+}
+
+type readYourWritesKey struct{}
+
+type readYourWrites struct {
+	lastWrite int64
+}
+
+// ContextWithReadYourWrites returns a context that remembers writes made through it, reads
+// with the same context go to the primary for Options.ReadYourWritesWindow after a write.
+// Attach it once per unit of work, e.g. per request in a middleware, and pass the returned
+// context to every WithContext call
+func ContextWithReadYourWrites(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if _, ok := ctx.Value(readYourWritesKey{}).(*readYourWrites); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, readYourWritesKey{}, &readYourWrites{})
+}
+
+func markWritten(ctx context.Context) {
+	if ryw, ok := ctx.Value(readYourWritesKey{}).(*readYourWrites); ok {
+		atomic.StoreInt64(&ryw.lastWrite, time.Now().UnixNano())
+	}
+}
+
+func recentlyWritten(ctx context.Context, window time.Duration) bool {
+	if window <= 0 {
+		return false
+	}
+	ryw, ok := ctx.Value(readYourWritesKey{}).(*readYourWrites)
+	if !ok {
+		return false
+	}
+	lastWrite := atomic.LoadInt64(&ryw.lastWrite)
+	return lastWrite > 0 && time.Since(time.Unix(0, lastWrite)) < window
+}
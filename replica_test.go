@@ -0,0 +1,98 @@
+package zinc
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+)
+
+func openTestReplicas(t *testing.T, n int, opts *Options) (*DB, *testDriver, []*testDriver) {
+	t.Helper()
+	primary, pd := openTestDB(t)
+	var replicas []*sql.DB
+	var rds []*testDriver
+	for i := 0; i < n; i++ {
+		r, rd := openTestDB(t)
+		replicas = append(replicas, r)
+		rds = append(rds, rd)
+	}
+	db, err := NewWithReplicas("sqlite3", primary, replicas, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cols := []testColumn{{name: "n", typeName: "INTEGER"}}
+	for _, d := range append([]*testDriver{pd}, rds...) {
+		d.setRows(cols, []driver.Value{int64(1)})
+	}
+	return db, pd, rds
+}
+
+func countLogged(d *testDriver, prefix string) int {
+	n := 0
+	for _, s := range d.logged() {
+		if strings.HasPrefix(s, prefix) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestReadYourWrites(t *testing.T) {
+	db, pd, rds := openTestReplicas(t, 1, &Options{ReadYourWritesWindow: time.Minute})
+	var n int64
+
+	// separate WithContext calls on the same request context
+	ctx := ContextWithReadYourWrites(context.Background())
+	if err := db.WithContext(ctx).RawExec(nil, "UPDATE t SET n = 1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.WithContext(ctx).RawQueryOne(&n, "SELECT n FROM t"); err != nil {
+		t.Fatal(err)
+	}
+	if countLogged(pd, "QUERY") != 1 || countLogged(rds[0], "QUERY") != 0 {
+		t.Fatalf("read after write did not go to the primary: %v / %v", pd.logged(), rds[0].logged())
+	}
+
+	// without the marker reads go to the replica even after a write
+	bg := context.Background()
+	if err := db.WithContext(bg).RawExec(nil, "UPDATE t SET n = 2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.WithContext(bg).RawQueryOne(&n, "SELECT n FROM t"); err != nil {
+		t.Fatal(err)
+	}
+	if countLogged(rds[0], "QUERY") != 1 {
+		t.Fatalf("read did not go to the replica: %v", rds[0].logged())
+	}
+}
+
+func TestReplicaLeastInflight(t *testing.T) {
+	db, _, rds := openTestReplicas(t, 2, &Options{ReplicaPolicy: ReplicaLeastInflight})
+	// while the rows of the outer query are open, every inner query must avoid its replica
+	err := db.RawQueryAll(func(rows *sql.Rows) error {
+		for i := 0; i < 4; i++ {
+			var n int64
+			if err := db.RawQueryOne(&n, "SELECT inner"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, "SELECT outer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rd := range rds {
+		outer, inner := countLogged(rd, "QUERY SELECT outer"), countLogged(rd, "QUERY SELECT inner")
+		if !(outer == 1 && inner == 0 || outer == 0 && inner == 4) {
+			t.Fatalf("outer %d, inner %d: %v", outer, inner, rd.logged())
+		}
+	}
+	for _, r := range db.replicas.replicas {
+		if r.inflight != 0 {
+			t.Fatalf("inflight = %d after the rows were closed", r.inflight)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package zinc
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+func (db *DB) Prepare(q string) (*DB, error) {
+	bound, names, err := db.Dialect().CompileNamedQuery(q, db.options)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrBind, err.Error())
+	}
+	bound = rebind(db.Dialect(), bound, db.options)
+	var prepared *DB
+	switch db.kind {
+	case kindDB:
+		st, err := db.db.PrepareContext(db.getCtx(), bound)
+		if err != nil {
+			return nil, err
+		}
+		prepared = db.clone(kindSt, db.db, nil, nil, st)
+	case kindTx:
+		st, err := db.tx.PrepareContext(db.getCtx(), bound)
+		if err != nil {
+			return nil, err
+		}
+		prepared = db.clone(kindSt, db.db, db.conn, db.tx, st)
+	case kindCn:
+		st, err := db.conn.PrepareContext(db.getCtx(), bound)
+		if err != nil {
+			return nil, err
+		}
+		prepared = db.clone(kindSt, db.db, db.conn, nil, st)
+	default:
+		return nil, ErrInvalidKind
+	}
+	prepared.stQuery = bound
+	prepared.stNames = names
+	return prepared, nil
+}
+
+func (db *DB) bindStmt(uArgs UnitedArgs) (string, []any, error) {
+	boundArgs := append([]any{}, uArgs.Unnamed...)
+	if len(db.stNames) > 0 {
+		nativeNamed := isNativeNamedBind(db.Dialect())
+		seen := map[string]bool{}
+		for _, name := range db.stNames {
+			argVal, ok := uArgs.Named[name]
+			if !ok {
+				return "", nil, fmt.Errorf("missing named arg %s", name)
+			}
+			if nativeNamed {
+				if !seen[name] {
+					seen[name] = true
+					boundArgs = append(boundArgs, sql.Named(name, argVal))
+				}
+			} else {
+				boundArgs = append(boundArgs, argVal)
+			}
+		}
+	} else if uArgs.HasNamed() {
+		return "", nil, errors.New("prepared statement has no named params")
+	}
+	for i, arg := range boundArgs {
+		if na, ok := arg.(sql.NamedArg); ok {
+			arg = na.Value
+		}
+		if _, ok := asSliceForIn(arg); ok {
+			return "", nil, fmt.Errorf("slice arg #%d can not be expanded in prepared statement", i)
+		}
+	}
+	return db.stQuery, boundArgs, nil
+}
@@ -0,0 +1,221 @@
+package zinc
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"sync"
+)
+
+type StmtCacheStats struct {
+	Size      int
+	Capacity  int
+	Hits      int64
+	Misses    int64
+	Evictions int64
+}
+
+type stmtCache struct {
+	mu        sync.Mutex
+	capacity  int
+	ll        *list.List
+	items     map[string]*list.Element
+	hits      int64
+	misses    int64
+	evictions int64
+}
+
+type stmtCacheEntry struct {
+	query   string
+	st      *sql.Stmt
+	refs    int
+	evicted bool
+}
+
+func newStmtCache(capacity int) *stmtCache {
+	return &stmtCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    map[string]*list.Element{},
+	}
+}
+
+func (c *stmtCache) exec(ctx context.Context, db *sql.DB, tx *sql.Tx, q string, args []any) (sql.Result, error) {
+	st, release, err := c.stmt(ctx, db, tx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	if st == nil {
+		return tx.ExecContext(ctx, q, args...)
+	}
+	return st.ExecContext(ctx, args...)
+}
+
+// query runs q on a cached statement, release must be called after the rows are closed
+func (c *stmtCache) query(ctx context.Context, db *sql.DB, tx *sql.Tx, q string, args []any) (*sql.Rows, func(), error) {
+	st, release, err := c.stmt(ctx, db, tx, q)
+	if err != nil {
+		return nil, func() {}, err
+	}
+	var rows *sql.Rows
+	if st == nil {
+		rows, err = tx.QueryContext(ctx, q, args...)
+	} else {
+		rows, err = st.QueryContext(ctx, args...)
+	}
+	if err != nil {
+		release()
+		return nil, func() {}, err
+	}
+	return rows, release, nil
+}
+
+// stmt returns the cached statement for q. In a transaction it is only reused, the transaction
+// holds its connection and preparing on the pool could wait for a second one forever, so a miss
+// returns a nil statement and q runs on the transaction directly. Statements are cached by the
+// queries outside transactions
+func (c *stmtCache) stmt(ctx context.Context, db *sql.DB, tx *sql.Tx, q string) (*sql.Stmt, func(), error) {
+	if tx == nil {
+		e, err := c.acquire(ctx, db, q)
+		if err != nil {
+			return nil, nil, err
+		}
+		return e.st, func() { c.release(e) }, nil
+	}
+	e := c.lookup(q)
+	if e == nil {
+		return nil, func() {}, nil
+	}
+	txSt := tx.StmtContext(ctx, e.st)
+	return txSt, func() {
+		_ = txSt.Close()
+		c.release(e)
+	}, nil
+}
+
+// lookup returns the cached entry for q with a reference taken, or nil on a miss
+func (c *stmtCache) lookup(q string) *stmtCacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[q]; ok {
+		c.ll.MoveToFront(el)
+		e := el.Value.(*stmtCacheEntry)
+		e.refs++
+		c.hits++
+		return e
+	}
+	c.misses++
+	return nil
+}
+
+func (c *stmtCache) acquire(ctx context.Context, db *sql.DB, q string) (*stmtCacheEntry, error) {
+	if e := c.lookup(q); e != nil {
+		return e, nil
+	}
+
+	st, err := db.PrepareContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	var closing []*sql.Stmt
+	c.mu.Lock()
+	var e *stmtCacheEntry
+	if el, ok := c.items[q]; ok {
+		// prepared concurrently by another caller
+		c.ll.MoveToFront(el)
+		e = el.Value.(*stmtCacheEntry)
+		e.refs++
+		closing = append(closing, st)
+	} else {
+		e = &stmtCacheEntry{query: q, st: st, refs: 1}
+		c.items[q] = c.ll.PushFront(e)
+		for c.ll.Len() > c.capacity {
+			if evicted := c.evictOldest(); evicted != nil {
+				closing = append(closing, evicted)
+			}
+		}
+	}
+	c.mu.Unlock()
+	closeStmts(closing)
+	return e, nil
+}
+
+func (c *stmtCache) release(e *stmtCacheEntry) {
+	var closing *sql.Stmt
+	c.mu.Lock()
+	e.refs--
+	if e.evicted && e.refs <= 0 {
+		closing = e.st
+	}
+	c.mu.Unlock()
+	if closing != nil {
+		_ = closing.Close()
+	}
+}
+
+func (c *stmtCache) evictOldest() *sql.Stmt {
+	el := c.ll.Back()
+	if el == nil {
+		return nil
+	}
+	e := el.Value.(*stmtCacheEntry)
+	c.ll.Remove(el)
+	delete(c.items, e.query)
+	e.evicted = true
+	c.evictions++
+	if e.refs > 0 {
+		// closed by the last release
+		return nil
+	}
+	return e.st
+}
+
+func (c *stmtCache) close() error {
+	var closing []*sql.Stmt
+	c.mu.Lock()
+	for c.ll.Len() > 0 {
+		if evicted := c.evictOldest(); evicted != nil {
+			closing = append(closing, evicted)
+		}
+	}
+	c.mu.Unlock()
+	return closeStmts(closing)
+}
+
+func (c *stmtCache) stats() StmtCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return StmtCacheStats{
+		Size:      c.ll.Len(),
+		Capacity:  c.capacity,
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+	}
+}
+
+func closeStmts(stmts []*sql.Stmt) error {
+	var firstErr error
+	for _, st := range stmts {
+		if err := st.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (db *DB) stmtCacheOf(opts *Options) *stmtCache {
+	if db.stmts == nil || opts.StmtCacheSize <= 0 {
+		return nil
+	}
+	return db.stmts
+}
+
+func (db *DB) StmtCacheStats() StmtCacheStats {
+	if db.stmts == nil {
+		return StmtCacheStats{}
+	}
+	return db.stmts.stats()
+}
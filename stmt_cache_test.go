@@ -0,0 +1,142 @@
+package zinc
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStmtCacheEviction(t *testing.T) {
+	sqlDB, d := openTestDB(t)
+	c := newStmtCache(2)
+	ctx := context.Background()
+	for _, q := range []string{"A", "B", "A", "C"} {
+		if _, err := c.exec(ctx, sqlDB, nil, q, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// B is the least recently used when C comes in
+	stats := c.stats()
+	want := StmtCacheStats{Size: 2, Capacity: 2, Hits: 1, Misses: 3, Evictions: 1}
+	if stats != want {
+		t.Fatalf("stats = %+v, want %+v", stats, want)
+	}
+	if _, ok := c.items["B"]; ok {
+		t.Fatal("B is still cached")
+	}
+	if !sliceContains(d.logged(), "CLOSE B") {
+		t.Fatalf("B was not closed: %v", d.logged())
+	}
+	if err := c.close(); err != nil {
+		t.Fatal(err)
+	}
+	if c.stats().Size != 0 {
+		t.Fatal("cache is not empty after close")
+	}
+}
+
+func TestStmtCacheRefcount(t *testing.T) {
+	sqlDB, d := openTestDB(t)
+	c := newStmtCache(1)
+	ctx := context.Background()
+	st, release, err := c.stmt(ctx, sqlDB, nil, "A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// evict A while it is in use
+	if _, err := c.exec(ctx, sqlDB, nil, "B", nil); err != nil {
+		t.Fatal(err)
+	}
+	if sliceContains(d.logged(), "CLOSE A") {
+		t.Fatal("A was closed while in use")
+	}
+	if _, err := st.ExecContext(ctx); err != nil {
+		t.Fatal(err)
+	}
+	release()
+	if !sliceContains(d.logged(), "CLOSE A") {
+		t.Fatalf("A was not closed by the last release: %v", d.logged())
+	}
+}
+
+func TestStmtCacheInTxSingleConn(t *testing.T) {
+	sqlDB, _ := openTestDB(t)
+	sqlDB.SetMaxOpenConns(1)
+	db, err := New("sqlite3", sqlDB, &Options{StmtCacheSize: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := db.WithContext(ctx).RawExec(nil, "UPDATE a SET x = ?", 1); err != nil {
+		t.Fatal(err)
+	}
+	err = db.Transaction(ctx, func(tx *DB) error {
+		// cached and uncached statements, and a savepoint
+		if err := tx.RawExec(nil, "UPDATE a SET x = ?", 2); err != nil {
+			return err
+		}
+		if err := tx.RawExec(nil, "UPDATE b SET x = ?", 2); err != nil {
+			return err
+		}
+		return tx.Transaction(ctx, func(tx *DB) error {
+			return tx.RawExec(nil, "UPDATE c SET x = ?", 3)
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStmtCacheQueryInTx(t *testing.T) {
+	sqlDB, d := openTestDB(t)
+	sqlDB.SetMaxOpenConns(1)
+	db, err := New("sqlite3", sqlDB, &Options{StmtCacheSize: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.setRows([]testColumn{{name: "n", typeName: "INTEGER"}}, []driver.Value{int64(1)}, []driver.Value{int64(2)})
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	var ns []int64
+	if err := db.WithContext(ctx).RawQueryAll(&ns, "SELECT n FROM a"); err != nil {
+		t.Fatal(err)
+	}
+	err = db.Transaction(ctx, func(tx *DB) error {
+		for _, q := range []string{"SELECT n FROM a", "SELECT n FROM b"} {
+			ns = nil
+			if err := tx.RawQueryAll(&ns, q); err != nil {
+				return err
+			}
+			if len(ns) != 2 || ns[1] != 2 {
+				t.Fatalf("%s: %v", q, ns)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	logged := d.logged()
+	// no statement is closed while its rows are read
+	reading := false
+	for _, s := range logged {
+		switch {
+		case strings.HasPrefix(s, "STMT QUERY ") || strings.HasPrefix(s, "QUERY "):
+			reading = true
+		case s == "ROWS CLOSE":
+			reading = false
+		case strings.HasPrefix(s, "CLOSE ") && reading:
+			t.Fatalf("statement closed before its rows: %v", logged)
+		}
+	}
+	// a miss runs on the transaction, it is neither prepared nor cached
+	if sliceContains(logged, "PREPARE SELECT n FROM b") || !sliceContains(logged, "QUERY SELECT n FROM b") {
+		t.Fatalf("miss in tx: %v", logged)
+	}
+	if stats := db.StmtCacheStats(); stats.Size != 1 || stats.Hits != 1 {
+		t.Fatalf("stats = %+v", stats)
+	}
+}
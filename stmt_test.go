@@ -0,0 +1,93 @@
+package zinc
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPrepareNamed(t *testing.T) {
+	sqlDB, d := openTestDB(t)
+	db, err := New("mysql", sqlDB, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := db.Prepare("UPDATE t SET a = :a, b = :b WHERE a <> :a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = st.Close() }()
+	if !sliceContains(d.logged(), "PREPARE UPDATE t SET a = ?, b = ? WHERE a <> ?") {
+		t.Fatalf("log = %v", d.logged())
+	}
+	// the args are bound in the order the names were remembered at Prepare
+	for i := 0; i < 2; i++ {
+		if err := st.RawExec(nil, "", map[string]any{"b": 2, "a": 1}); err != nil {
+			t.Fatal(err)
+		}
+		var got []any
+		for _, nv := range d.lastArgs() {
+			got = append(got, nv.Value)
+		}
+		if !reflect.DeepEqual(got, []any{1, 2, 1}) {
+			t.Fatalf("args = %v", got)
+		}
+	}
+	if countExact(d, "STMT EXEC UPDATE t SET a = ?, b = ? WHERE a <> ?") != 2 {
+		t.Fatalf("log = %v", d.logged())
+	}
+
+	if err := st.RawExec(nil, "", map[string]any{"a": 1}); !errors.Is(err, ErrBind) {
+		t.Fatalf("missing named arg: %v", err)
+	}
+	if err := st.RawExec(nil, "", map[string]any{"a": []int{1, 2}, "b": 2}); !errors.Is(err, ErrBind) {
+		t.Fatalf("slice arg: %v", err)
+	}
+}
+
+func TestPreparePositional(t *testing.T) {
+	sqlDB, d := openTestDB(t)
+	db, err := New("mysql", sqlDB, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := db.Prepare("SELECT n FROM t WHERE id IN (?)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = st.Close() }()
+	d.setRows([]testColumn{{name: "n", typeName: "BIGINT"}}, []driver.Value{int64(3)})
+	var n int64
+	if err := st.RawQueryOne(&n, "", 1); err != nil || n != 3 {
+		t.Fatalf("n = %d, %v", n, err)
+	}
+	// IN can't be expanded, the statement is already prepared
+	if err := st.RawQueryOne(&n, "", []int{1, 2}); !errors.Is(err, ErrBind) {
+		t.Fatalf("slice arg: %v", err)
+	}
+	if err := st.RawQueryOne(&n, "", map[string]any{"id": 1}); !errors.Is(err, ErrBind) {
+		t.Fatalf("named arg: %v", err)
+	}
+}
+
+func TestPrepareNativeNamed(t *testing.T) {
+	sqlDB, d := openTestDB(t)
+	db, err := New("godror", sqlDB, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st, err := db.Prepare("UPDATE t SET a = :a, b = :b WHERE a <> :a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = st.Close() }()
+	if err := st.RawExec(nil, "", map[string]any{"b": 2, "a": 1}); err != nil {
+		t.Fatal(err)
+	}
+	// each name is bound once, by name
+	args := d.lastArgs()
+	if len(args) != 2 || args[0].Name != "a" || args[0].Value != 1 || args[1].Name != "b" || args[1].Value != 2 {
+		t.Fatalf("args = %+v", args)
+	}
+}
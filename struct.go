@@ -1,7 +1,6 @@
 package zinc
 
 import (
-	"fmt"
 	"reflect"
 	"sync"
 )
@@ -61,7 +60,6 @@ func parseStruct1(t reflect.Type) *Struct {
 }
 
 func parseStruct0(t reflect.Type) *Struct {
-	fmt.Println("**********")
 	s := &Struct{
 		Name: t.Name(),
 	}
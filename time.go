@@ -0,0 +1,103 @@
+package zinc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	zonedTimeLayouts = []string{
+		"2006-01-02 15:04:05.999999999Z07:00",
+		"2006-01-02T15:04:05.999999999Z07:00",
+	}
+	naiveTimeLayouts = []string{
+		"2006-01-02 15:04:05.999999999",
+		"2006-01-02T15:04:05.999999999",
+		"2006-01-02 15:04",
+		"2006-01-02T15:04",
+		"2006-01-02",
+	}
+)
+
+func timeLocation(opts *Options) *time.Location {
+	if opts == nil || opts.TimeLocation == nil {
+		return time.UTC
+	}
+	return opts.TimeLocation
+}
+
+// parseTimeText parses DATE/DATETIME/TIMESTAMP text, naive values are interpreted in Options.TimeLocation
+func parseTimeText(s string, opts *Options) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if isZeroDateText(s) {
+		if opts != nil && opts.TimeRejectZeroDate {
+			return time.Time{}, fmt.Errorf("zero date %q", s)
+		}
+		return time.Time{}, nil
+	}
+	for _, layout := range zonedTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	loc := timeLocation(opts)
+	for _, layout := range naiveTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", s)
+}
+
+func isZeroDateText(s string) bool {
+	if !strings.HasPrefix(s, "0000-00-00") {
+		return false
+	}
+	return strings.Trim(s[len("0000-00-00"):], " T0:.") == ""
+}
+
+// parseDurationText parses TIME text like -838:59:59.000000
+func parseDurationText(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	invalid := func() (time.Duration, error) {
+		return 0, fmt.Errorf("invalid time of day %q", s)
+	}
+	neg := strings.HasPrefix(s, "-")
+	parts := strings.Split(strings.TrimPrefix(s, "-"), ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return invalid()
+	}
+	h, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return invalid()
+	}
+	m, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || m >= 60 {
+		return invalid()
+	}
+	d := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute
+	if len(parts) == 3 {
+		sec, frac, _ := strings.Cut(parts[2], ".")
+		n, err := strconv.ParseInt(sec, 10, 64)
+		if err != nil || n >= 60 {
+			return invalid()
+		}
+		d += time.Duration(n) * time.Second
+		if frac != "" {
+			if len(frac) > 9 {
+				frac = frac[:9]
+			}
+			ns, err := strconv.ParseInt(frac+strings.Repeat("0", 9-len(frac)), 10, 64)
+			if err != nil {
+				return invalid()
+			}
+			d += time.Duration(ns)
+		}
+	}
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
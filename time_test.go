@@ -0,0 +1,114 @@
+package zinc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDurationText(t *testing.T) {
+	tests := []struct {
+		s    string
+		want time.Duration
+		ok   bool
+	}{
+		{"00:00:00", 0, true},
+		{"12:34", 12*time.Hour + 34*time.Minute, true},
+		{"838:59:59", 838*time.Hour + 59*time.Minute + 59*time.Second, true},
+		{"-838:59:59.000000", -(838*time.Hour + 59*time.Minute + 59*time.Second), true},
+		{"01:02:03.5", time.Hour + 2*time.Minute + 3*time.Second + 500*time.Millisecond, true},
+		{"00:00:00.1234567891", 123456789, true},
+		{" 01:00:00 ", time.Hour, true},
+		{"", 0, false},
+		{"12", 0, false},
+		{"1:60", 0, false},
+		{"1:00:60", 0, false},
+		{"1:2:3:4", 0, false},
+		{"a:00:00", 0, false},
+		{"00:00:00.x", 0, false},
+	}
+	for _, tt := range tests {
+		got, err := parseDurationText(tt.s)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("parseDurationText(%q) = %v, %v", tt.s, got, err)
+		}
+	}
+}
+
+func TestIsZeroDateText(t *testing.T) {
+	tests := map[string]bool{
+		"0000-00-00":                 true,
+		"0000-00-00 00:00:00":        true,
+		"0000-00-00 00:00:00.000000": true,
+		"0000-00-00T00:00:00":        true,
+		"0000-00-00 00:00:01":        false,
+		"0001-01-01":                 false,
+		"2024-01-02":                 false,
+		"":                           false,
+	}
+	for s, want := range tests {
+		if got := isZeroDateText(s); got != want {
+			t.Errorf("isZeroDateText(%q) = %v", s, got)
+		}
+	}
+}
+
+func TestParseTimeText(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*3600)
+	opts := &Options{TimeLocation: loc}
+	tests := []struct {
+		s    string
+		want time.Time
+	}{
+		{"2024-01-02", time.Date(2024, 1, 2, 0, 0, 0, 0, loc)},
+		{"2024-01-02 03:04:05.123", time.Date(2024, 1, 2, 3, 4, 5, 123000000, loc)},
+		{"2024-01-02T03:04", time.Date(2024, 1, 2, 3, 4, 0, 0, loc)},
+		{"2024-01-02 03:04:05Z", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{"2024-01-02T03:04:05-05:00", time.Date(2024, 1, 2, 8, 4, 5, 0, time.UTC)},
+		{"0000-00-00 00:00:00", time.Time{}},
+	}
+	for _, tt := range tests {
+		got, err := parseTimeText(tt.s, opts)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseTimeText(%q) = %v, %v; want %v", tt.s, got, err, tt.want)
+		}
+	}
+	if _, err := parseTimeText("0000-00-00", &Options{TimeRejectZeroDate: true}); err == nil {
+		t.Error("zero date was not rejected")
+	}
+	if _, err := parseTimeText("yesterday", opts); err == nil {
+		t.Error("invalid time was accepted")
+	}
+	if got, _ := parseTimeText("2024-01-02", nil); got.Location() != time.UTC {
+		t.Errorf("default location = %v", got.Location())
+	}
+}
+
+func TestDialectTimeLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*3600)
+	opts := &Options{TimeLocation: loc, TimeRejectZeroDate: true}
+	naive := time.Date(2024, 1, 2, 3, 4, 5, 0, loc)
+	zoned := time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("", -5*3600))
+
+	pgTests := map[string]time.Time{
+		"2024-01-02 03:04:05":       naive,
+		"2024-01-02 03:04:05-05":    zoned,
+		"2024-01-02 03:04:05-05:00": zoned,
+	}
+	for s, want := range pgTests {
+		if got, err := parsePostgresTime(s, opts); err != nil || !got.Equal(want) {
+			t.Errorf("parsePostgresTime(%q) = %v, %v", s, got, err)
+		}
+	}
+	oraTests := map[string]time.Time{
+		"2024-01-02 03:04:05":        naive,
+		"2024-01-02 03:04:05 -05:00": zoned,
+	}
+	for s, want := range oraTests {
+		if got, err := parseOracleTime(s, opts); err != nil || !got.Equal(want) {
+			t.Errorf("parseOracleTime(%q) = %v, %v", s, got, err)
+		}
+	}
+	if _, err := parseOracleTime("0000-00-00", opts); err == nil {
+		t.Error("oracle zero date was not rejected")
+	}
+}
@@ -0,0 +1,211 @@
+package zinc
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+func (db *DB) Begin() (*DB, error) {
+	return db.BeginTx(nil)
+}
+
+func (db *DB) BeginTx(opts *sql.TxOptions) (*DB, error) {
+	var tx *sql.Tx
+	var err error
+	switch db.kind {
+	case kindDB:
+		tx, err = db.db.BeginTx(db.getCtx(), opts)
+	case kindCn:
+		tx, err = db.conn.BeginTx(db.getCtx(), opts)
+	default:
+		return nil, ErrInvalidKind
+	}
+	if err != nil {
+		return nil, err
+	}
+	cloned := db.clone(kindTx, db.db, db.conn, tx, nil)
+	cloned.txState = &txState{}
+	return cloned, nil
+}
+
+func (db *DB) Commit() error {
+	if db.kind != kindTx {
+		return ErrNotInTx
+	}
+	return db.tx.Commit()
+}
+
+func (db *DB) Rollback() error {
+	if db.kind != kindTx {
+		return ErrNotInTx
+	}
+	return db.tx.Rollback()
+}
+
+func (db *DB) InTx() bool {
+	return db.kind == kindTx
+}
+
+func (db *DB) Tx() *sql.Tx {
+	return db.tx
+}
+
+type txContextKey struct{}
+
+func ContextWithTx(ctx context.Context, tx *DB) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if tx == nil || tx.kind != kindTx {
+		return ctx
+	}
+	return context.WithValue(ctx, txContextKey{}, tx)
+}
+
+func TxFromContext(ctx context.Context) (*DB, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	tx, ok := ctx.Value(txContextKey{}).(*DB)
+	return tx, ok
+}
+
+func closeTx(tx *sql.Tx) error {
+	// rollback if it has not been committed or rolled back yet
+	if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		return err
+	}
+	return nil
+}
+
+func (db *DB) Transaction(ctx context.Context, f func(tx *DB) error) error {
+	return db.TransactionTx(ctx, nil, f)
+}
+
+func (db *DB) TransactionTx(ctx context.Context, opts *sql.TxOptions, f func(tx *DB) error) error {
+	if ctx == nil {
+		ctx = db.getCtx()
+	}
+	// join the transaction carried by ctx
+	db = db.WithContext(ctx)
+	switch db.kind {
+	case kindDB, kindCn:
+		return db.retryTx(ctx, func() (bool, error) {
+			tx, err := db.BeginTx(opts)
+			if err != nil {
+				return false, err
+			}
+			err = runTx(tx, f, tx.Commit, tx.Rollback)
+			return tx.txState.ran(), err
+		})
+	case kindTx:
+		tx := db.clone(kindTx, db.db, db.conn, db.tx, nil)
+		tx.txDepth++
+		sp := savepointName(tx.txDepth)
+		sd := savepointDialectOf(tx.Dialect())
+		if err := tx.RawExec(nil, sd.SavepointSQL(sp, tx.options)); err != nil {
+			return err
+		}
+		release := func() error {
+			q := sd.ReleaseSavepointSQL(sp, tx.options)
+			if q == "" {
+				return nil
+			}
+			return tx.RawExec(nil, q)
+		}
+		rollback := func() error {
+			return tx.RawExec(nil, sd.RollbackToSavepointSQL(sp, tx.options))
+		}
+		return runTx(tx, f, release, rollback)
+	default:
+		return ErrInvalidKind
+	}
+}
+
+func runTx(tx *DB, f func(tx *DB) error, commit, rollback func() error) (err error) {
+	committing := false
+	defer func() {
+		if committing {
+			return
+		}
+		// rollback on error or panic, the panic keeps propagating
+		if rbErr := rollback(); rbErr != nil && err != nil {
+			err = fmt.Errorf("%w (rollback: %s)", err, rbErr.Error())
+		}
+	}()
+	if err = f(tx); err != nil {
+		return err
+	}
+	committing = true
+	return commit()
+}
+
+func (db *DB) retryTx(ctx context.Context, attempt func() (bool, error)) error {
+	opts := db.options
+	for i := 1; ; i++ {
+		ran, err := attempt()
+		if err == nil || i >= opts.TxMaxAttempts || !isRetryableTxErr(db.Dialect(), err, ran) {
+			return err
+		}
+		backoff := txRetryBackoff(opts.TxRetryBackoff, i)
+		if opts.Logger != nil {
+			opts.Logger.LogQueryErr(ctx, fmt.Sprintf("retry transaction (%d/%d) in %dms: %s", i+1, opts.TxMaxAttempts, backoff.Milliseconds(), err.Error()))
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+func isRetryableTxErr(d Dialect, err error, ran bool) bool {
+	if errors.Is(err, driver.ErrBadConn) {
+		// the statements may have been applied if the connection died later
+		return !ran
+	}
+	if rd, ok := d.(RetryableErrorDialect); ok {
+		return rd.IsRetryableError(err)
+	}
+	return false
+}
+
+func txRetryBackoff(base time.Duration, attempt int) time.Duration {
+	if base <= 0 {
+		base = 20 * time.Millisecond
+	}
+	d := base << (attempt - 1)
+	if d <= 0 || d > 5*time.Second {
+		d = 5 * time.Second
+	}
+	// jitter in [d/2, d]
+	half := int64(d / 2)
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+type txState struct {
+	statements int64
+}
+
+func (s *txState) noteStatement() {
+	if s != nil {
+		atomic.AddInt64(&s.statements, 1)
+	}
+}
+
+func (s *txState) ran() bool {
+	return s != nil && atomic.LoadInt64(&s.statements) > 0
+}
+
+func savepointName(depth int) string {
+	return "zinc_sp_" + strconv.Itoa(depth)
+}
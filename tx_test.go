@@ -0,0 +1,240 @@
+package zinc
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+func newTxTestDB(t *testing.T, opts *Options) (*DB, *testDriver) {
+	t.Helper()
+	sqlDB, d := openTestDB(t)
+	db, err := New("mysql", sqlDB, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, d
+}
+
+// countExact counts the log entries equal to s
+func countExact(d *testDriver, s string) int {
+	n := 0
+	for _, e := range d.logged() {
+		if e == s {
+			n++
+		}
+	}
+	return n
+}
+
+func TestBeginCommitRollback(t *testing.T) {
+	db, d := newTxTestDB(t, nil)
+	if db.InTx() {
+		t.Fatal("db is in a tx")
+	}
+	if err := db.Commit(); !errors.Is(err, ErrNotInTx) {
+		t.Fatalf("Commit outside a tx: %v", err)
+	}
+	if err := db.Rollback(); !errors.Is(err, ErrNotInTx) {
+		t.Fatalf("Rollback outside a tx: %v", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tx.InTx() || tx.Tx() == nil {
+		t.Fatal("Begin did not return a tx")
+	}
+	if err := tx.RawExec(nil, "UPDATE t SET x = 1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.Begin(); !errors.Is(err, ErrInvalidKind) {
+		t.Fatalf("Begin in a tx: %v", err)
+	}
+
+	tx, err = db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	if countExact(d, "BEGIN") != 2 || countExact(d, "COMMIT") != 1 || countExact(d, "ROLLBACK") != 1 {
+		t.Fatalf("log = %v", d.logged())
+	}
+}
+
+func TestTransactionCommitAndRollback(t *testing.T) {
+	db, d := newTxTestDB(t, nil)
+	ctx := context.Background()
+	err := db.Transaction(ctx, func(tx *DB) error {
+		return tx.RawExec(nil, "UPDATE t SET x = 1")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"OPEN", "BEGIN", "EXEC UPDATE t SET x = 1", "COMMIT"}
+	if got := d.logged(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("log = %v", got)
+	}
+
+	errFailed := errors.New("failed")
+	err = db.Transaction(ctx, func(tx *DB) error {
+		return errFailed
+	})
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("err = %v", err)
+	}
+	if countExact(d, "ROLLBACK") != 1 || countExact(d, "COMMIT") != 1 {
+		t.Fatalf("log = %v", d.logged())
+	}
+}
+
+func TestTransactionPanic(t *testing.T) {
+	db, d := newTxTestDB(t, nil)
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Fatalf("recovered %v", r)
+		}
+		if countExact(d, "ROLLBACK") != 1 || countExact(d, "COMMIT") != 0 {
+			t.Fatalf("log = %v", d.logged())
+		}
+	}()
+	_ = db.Transaction(context.Background(), func(tx *DB) error {
+		panic("boom")
+	})
+	t.Fatal("the panic was swallowed")
+}
+
+func TestTransactionNested(t *testing.T) {
+	db, d := newTxTestDB(t, nil)
+	errInner := errors.New("inner")
+	ctx := context.Background()
+	err := db.Transaction(ctx, func(tx *DB) error {
+		if err := tx.Transaction(ctx, func(tx *DB) error {
+			return tx.Transaction(ctx, func(tx *DB) error {
+				return tx.RawExec(nil, "UPDATE t SET x = 1")
+			})
+		}); err != nil {
+			return err
+		}
+		// a failed nested transaction only rolls back to its savepoint
+		if err := tx.Transaction(ctx, func(tx *DB) error {
+			return errInner
+		}); !errors.Is(err, errInner) {
+			return fmt.Errorf("nested err = %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"OPEN", "BEGIN",
+		"EXEC SAVEPOINT `zinc_sp_1`",
+		"EXEC SAVEPOINT `zinc_sp_2`",
+		"EXEC UPDATE t SET x = 1",
+		"EXEC RELEASE SAVEPOINT `zinc_sp_2`",
+		"EXEC RELEASE SAVEPOINT `zinc_sp_1`",
+		"EXEC SAVEPOINT `zinc_sp_1`",
+		"EXEC ROLLBACK TO SAVEPOINT `zinc_sp_1`",
+		"COMMIT",
+	}
+	if got := d.logged(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("log = %q", got)
+	}
+}
+
+func TestTransactionRetry(t *testing.T) {
+	opts := &Options{TxMaxAttempts: 3, TxRetryBackoff: time.Millisecond}
+	deadlock := &mysql.MySQLError{Number: 1213, Message: "Deadlock found"}
+	lockWait := &mysql.MySQLError{Number: 1205, Message: "Lock wait timeout exceeded"}
+	duplicate := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}
+	tests := []struct {
+		name  string
+		errs  []error // returned by the attempts in order, nil after the last
+		exec  bool    // run a statement before returning the error
+		begin int
+		fails bool
+	}{
+		{"deadlock", []error{deadlock, deadlock, deadlock}, false, 3, true},
+		{"lock wait", []error{lockWait}, false, 2, false},
+		{"not retryable", []error{duplicate}, false, 1, true},
+		{"bad conn before statements", []error{driver.ErrBadConn}, false, 2, false},
+		{"bad conn after a statement", []error{driver.ErrBadConn}, true, 1, true},
+	}
+	for _, tt := range tests {
+		db, d := newTxTestDB(t, opts)
+		attempt := 0
+		err := db.Transaction(context.Background(), func(tx *DB) error {
+			attempt++
+			if attempt > len(tt.errs) {
+				return nil
+			}
+			if tt.exec {
+				if err := tx.RawExec(nil, "UPDATE t SET x = 1"); err != nil {
+					return err
+				}
+			}
+			return tt.errs[attempt-1]
+		})
+		if (err != nil) != tt.fails {
+			t.Errorf("%s: err = %v", tt.name, err)
+		}
+		if n := countExact(d, "BEGIN"); n != tt.begin {
+			t.Errorf("%s: %d attempts, want %d", tt.name, n, tt.begin)
+		}
+	}
+}
+
+func TestContextWithTx(t *testing.T) {
+	db, d := newTxTestDB(t, nil)
+	if ctx := ContextWithTx(context.Background(), db); ctx != context.Background() {
+		t.Fatal("a non-tx DB was attached to the context")
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := ContextWithTx(context.Background(), tx)
+	if got, ok := TxFromContext(ctx); !ok || got != tx {
+		t.Fatal("TxFromContext did not return the tx")
+	}
+
+	// repository code only sees db and the context
+	repo := func(ctx context.Context) error {
+		return db.WithContext(ctx).RawExec(nil, "UPDATE t SET x = 1")
+	}
+	if !db.WithContext(ctx).InTx() {
+		t.Fatal("WithContext did not join the tx")
+	}
+	if err := repo(ctx); err != nil {
+		t.Fatal(err)
+	}
+	// Transaction with the context nests in the tx with a savepoint
+	if err := db.Transaction(ctx, func(tx *DB) error { return repo(ctx) }); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if countExact(d, "BEGIN") != 1 || countExact(d, "EXEC SAVEPOINT `zinc_sp_1`") != 1 || countExact(d, "EXEC UPDATE t SET x = 1") != 2 {
+		t.Fatalf("log = %v", d.logged())
+	}
+
+	// a tx of another pool is not joined
+	other, _ := newTxTestDB(t, nil)
+	if other.WithContext(ctx).InTx() {
+		t.Fatal("joined the tx of another pool")
+	}
+}
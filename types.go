@@ -2,6 +2,9 @@ package zinc
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"math/big"
 	"reflect"
 	"time"
 )
@@ -25,4 +28,11 @@ var (
 	typRawBytes = reflect.TypeOf(sql.RawBytes{})
 	typAny      = reflect.TypeOf((*any)(nil)).Elem()
 	typTime     = reflect.TypeOf(time.Time{})
+	typDuration = reflect.TypeOf(time.Duration(0))
+	typDriver   = reflect.TypeOf((*driver.Driver)(nil)).Elem()
+	typScanner  = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	typValuer   = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+	typBigRat         = reflect.TypeOf((*big.Rat)(nil))
+	typJSONRawMessage = reflect.TypeOf(json.RawMessage{})
 )
@@ -62,7 +62,7 @@ func isPrimitive(t reflect.Type) bool {
 	case reflect.Float32, reflect.Float64:
 		return true
 	default:
-		if t == typBytes || t == typRawBytes {
+		if t == typBytes || t == typRawBytes || t == typTime {
 			return true
 		} else {
 			return false
@@ -117,6 +117,44 @@ func isSlicePtr(t reflect.Type) (reflect.Type, bool) {
 	return et, true
 }
 
+// isScalar reports whether t is mapped from a single column: a primitive, a sql.Scanner
+// or a type with a registered converter
+func isScalar(t reflect.Type, opts *Options) bool {
+	return isPrimitive(t) || isScanner(t) || hasFromDBConverter(t, opts)
+}
+
+// isScanner reports whether t, or a pointer to it, implements sql.Scanner
+func isScanner(t reflect.Type) bool {
+	return reflect.PtrTo(t).Implements(typScanner) || (t.Kind() == reflect.Ptr && t.Implements(typScanner))
+}
+
+func isScalarPtr(t reflect.Type, opts *Options) (reflect.Type, bool) {
+	if t.Kind() != reflect.Ptr {
+		return nil, false
+	}
+	et := t.Elem()
+	if !isScalar(et, opts) {
+		return nil, false
+	}
+	return et, true
+}
+
+// isNullableScalar reports whether t is a pointer to a scalar, NULL is mapped to a nil pointer
+func isNullableScalar(t reflect.Type, opts *Options) bool {
+	return t.Kind() == reflect.Ptr && isScalar(t.Elem(), opts)
+}
+
+func isNullableScalarPtr(t reflect.Type, opts *Options) (reflect.Type, bool) {
+	if t.Kind() != reflect.Ptr {
+		return nil, false
+	}
+	et := t.Elem()
+	if !isNullableScalar(et, opts) {
+		return nil, false
+	}
+	return et, true
+}
+
 func isStruct(t reflect.Type) bool {
 	return t.Kind() == reflect.Struct
 }
@@ -148,11 +186,6 @@ func sliceContains[T comparable](slice []T, target T) bool {
 	return false
 }
 
-func b2s(b []byte, charset string) (string, bool) {
-	// TODO: charset
-	return string(b), true
-}
-
 func lockR(m *sync.RWMutex, f func()) {
 	m.RLock()
 	defer m.RUnlock()